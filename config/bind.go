@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Binding 与 Manager 绑定的类型化配置
+// 每次成功加载后自动重新解码并原子替换，Load 无锁且总是返回完整的结构体
+type Binding[T any] struct {
+	key string
+	ptr atomic.Pointer[T]
+}
+
+// Load 返回当前配置解码出的结构体
+// 返回值在配置更新后不会被修改，调用方不应修改其内容
+func (b *Binding[T]) Load() *T {
+	return b.ptr.Load()
+}
+
+// Key 返回绑定的配置前缀
+func (b *Binding[T]) Key() string {
+	return b.key
+}
+
+// decode 解码配置并按 validate 标签校验，返回提交函数；失败时不修改当前值
+func (b *Binding[T]) decode(c *configImpl, provenance map[string]Provenance) (func(), error) {
+	v := new(T)
+	if err := c.Unmarshal(b.key, v); err != nil {
+		return nil, fmt.Errorf("failed to bind %q: %w", b.key, err)
+	}
+	if err := ValidateStruct(b.key, v); err != nil {
+		return nil, fmt.Errorf("failed to bind %q: %w", b.key, annotateSources(err, provenance))
+	}
+	return func() { b.ptr.Store(v) }, nil
+}
+
+// knownKeys 返回 T 推导出的已知 key，供严格模式使用
+func (b *Binding[T]) knownKeys() []string {
+	return KeysOf[T](b.key)
+}
+
+// binder 类型擦除后的 Binding，供 Manager 在加载时统一解码
+type binder interface {
+	decode(c *configImpl, provenance map[string]Provenance) (func(), error)
+	knownKeys() []string
+}
+
+// Bind 将 key 前缀下的配置绑定到类型 T（key 为空表示整个配置）
+// 已加载配置时立即解码，否则 Load 返回零值直到首次加载；之后每次 Load 或热更新成功后重新解码并原子替换
+// 结构体的 validate 标签同样参与校验，任一绑定解码或校验失败时本次加载失败，配置和所有绑定保持原值
+func Bind[T any](m *Manager, key string) (*Binding[T], error) {
+	b := &Binding[T]{key: key}
+	b.ptr.Store(new(T))
+
+	// 持有 loadMu 避免与正在构建的候选配置交错，新绑定不会错过进行中的加载
+	m.loadMu.Lock()
+	defer m.loadMu.Unlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.loaded {
+		commit, err := b.decode(m.config, m.provenance)
+		if err != nil {
+			return nil, err
+		}
+		commit()
+	}
+	m.bindings = append(m.bindings, b)
+	return b, nil
+}
+
+// decodeBindings 按新配置解码所有绑定，全部成功后返回统一的提交函数
+func decodeBindings(bindings []binder, c *configImpl, provenance map[string]Provenance) (func(), error) {
+	commits := make([]func(), 0, len(bindings))
+	for _, b := range bindings {
+		commit, err := b.decode(c, provenance)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, commit)
+	}
+	return func() {
+		for _, commit := range commits {
+			commit()
+		}
+	}, nil
+}
@@ -0,0 +1,166 @@
+package config
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+type bindDatabaseConfig struct {
+	Host string `mapstructure:"host"`
+	Port int    `mapstructure:"port"`
+}
+
+func TestBind(t *testing.T) {
+	source := &mockSource{
+		name: "file",
+		data: map[string]Value{
+			"database.host": NewValue("localhost"),
+			"database.port": NewValueFromInterface(5432),
+		},
+	}
+
+	t.Run("before_load", func(t *testing.T) {
+		m := NewManager()
+		b, err := Bind[bindDatabaseConfig](m, "database")
+		if err != nil {
+			t.Fatalf("Bind() error = %v", err)
+		}
+		if got := b.Load(); got == nil || *got != (bindDatabaseConfig{}) {
+			t.Errorf("Load() = %+v, want zero value", got)
+		}
+
+		m.AddSource(source)
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if got := *b.Load(); got != (bindDatabaseConfig{Host: "localhost", Port: 5432}) {
+			t.Errorf("Load() = %+v after Manager.Load()", got)
+		}
+	})
+
+	t.Run("after_load", func(t *testing.T) {
+		m := NewManager()
+		m.AddSource(source)
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		b, err := Bind[bindDatabaseConfig](m, "database")
+		if err != nil {
+			t.Fatalf("Bind() error = %v", err)
+		}
+		if b.Key() != "database" || b.Load().Host != "localhost" {
+			t.Errorf("Load() = %+v", b.Load())
+		}
+	})
+
+	t.Run("decode_error", func(t *testing.T) {
+		m := NewManager()
+		m.AddSource(&mockSource{
+			name: "file",
+			data: map[string]Value{"database.port": NewValue("not-a-number")},
+		})
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if _, err := Bind[bindDatabaseConfig](m, "database"); err == nil {
+			t.Error("Bind() should fail when config cannot be decoded")
+		}
+	})
+}
+
+func TestBind_Reload(t *testing.T) {
+	watcher := &mockWatcher{}
+	source := &mockSource{
+		name: "file",
+		data: map[string]Value{
+			"database.host": NewValue("v1"),
+			"database.port": NewValueFromInterface(1),
+		},
+		watcher: watcher,
+	}
+
+	m := NewManager()
+	m.AddSource(source)
+	if err := m.Load(context.Background()); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	b, err := Bind[bindDatabaseConfig](m, "database")
+	if err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+
+	reloaded := make(chan Event, 10)
+	m.OnChange(func(event Event, oldConfig, newConfig Config) {
+		reloaded <- event
+	})
+	if err := m.Watch(); err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer m.Close()
+
+	// 并发读取不应看到半更新的结构体
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				cfg := b.Load()
+				if (cfg.Host == "v1") != (cfg.Port == 1) {
+					t.Errorf("torn read: %+v", *cfg)
+					return
+				}
+			}
+		}()
+	}
+
+	reload := func(data map[string]Value) Event {
+		t.Helper()
+		source.data = data
+		watcher.eventCh <- Event{Type: EventTypeUpdate, Source: "file"}
+		select {
+		case event := <-reloaded:
+			return event
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for reload")
+			return Event{}
+		}
+	}
+
+	event := reload(map[string]Value{
+		"database.host": NewValue("v2"),
+		"database.port": NewValueFromInterface(2),
+	})
+	if event.Type != EventTypeUpdate {
+		t.Errorf("event = %+v, want update", event)
+	}
+	if got := *b.Load(); got != (bindDatabaseConfig{Host: "v2", Port: 2}) {
+		t.Errorf("Load() = %+v after reload", got)
+	}
+
+	// 解码失败时保留配置和绑定的原值
+	event = reload(map[string]Value{
+		"database.host": NewValue("v3"),
+		"database.port": NewValue("bad"),
+	})
+	if event.Type != EventTypeError || event.Error == nil {
+		t.Errorf("event = %+v, want error event", event)
+	}
+	if got := *b.Load(); got != (bindDatabaseConfig{Host: "v2", Port: 2}) {
+		t.Errorf("Load() = %+v, want previous value", got)
+	}
+	if got := m.Config().GetString("database.host", ""); got != "v2" {
+		t.Errorf("database.host = %q, want previous value", got)
+	}
+
+	close(stop)
+	wg.Wait()
+}
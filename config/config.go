@@ -2,6 +2,8 @@ package config
 
 import (
 	"context"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -27,6 +29,13 @@ type Source interface {
 	Watch() Watcher
 }
 
+// KeyLocator 配置源可选实现的接口
+// 返回该配置源中能够提供 key 的位置（如环境变量名、Consul 路径），无法提供时返回空字符串
+// 用于必需配置缺失时提示用户在哪里设置
+type KeyLocator interface {
+	Locate(key string) string
+}
+
 // Watcher 定义配置变更监听接口
 type Watcher interface {
 	// Start 启动监听
@@ -43,10 +52,12 @@ type Event struct {
 	Type EventType
 
 	// Source 触发事件的配置源名称
+	// 开启防抖后合并了多个配置源的事件时为空
 	Source string
 
 	// Keys 发生变更的配置键列表（可选）
 	// 如果为空，表示需要重新加载整个配置源
+	// Manager 传递给回调的事件中为实际发生变化的 key
 	Keys []string
 
 	// Timestamp 事件发生时间
@@ -54,6 +65,10 @@ type Event struct {
 
 	// Error 如果监听过程中发生错误
 	Error error
+
+	// Changes 重载前后合并配置的实际变化
+	// 仅由 Manager 在传递给回调前填充，配置源无需设置
+	Changes ChangeSet
 }
 
 // EventType 事件类型枚举
@@ -112,12 +127,56 @@ type Config interface {
 	GetDuration(key string, defaultVal time.Duration) time.Duration
 
 	// GetStringSlice 获取字符串切片
-	// 支持 JSON 数组格式或逗号分隔格式
+	// 支持 JSON 数组格式或逗号分隔格式，以及 key.0、key.1 等下标子 key
 	GetStringSlice(key string, defaultVal []string) []string
 
 	// GetStringMap 获取字符串映射
+	// key 本身不存在时，由 key 前缀下的扁平化子 key 重建映射
+	// 例如 "labels.team" 和 "labels.env" 组成 GetStringMap("labels", nil)
 	GetStringMap(key string, defaultVal map[string]string) map[string]string
 
+	// GetStringE 获取字符串值
+	// 以下 E 系列方法在 key 不存在时返回 ErrKeyNotFound，转换失败时返回 ErrTypeMismatch
+	// 错误类型为 *KeyError，包含出错的 key 与原始值
+	GetStringE(key string) (string, error)
+
+	// GetIntE 获取整数值
+	GetIntE(key string) (int, error)
+
+	// GetInt64E 获取 int64 值
+	GetInt64E(key string) (int64, error)
+
+	// GetFloat64E 获取浮点数值
+	GetFloat64E(key string) (float64, error)
+
+	// GetBoolE 获取布尔值
+	GetBoolE(key string) (bool, error)
+
+	// GetDurationE 获取时间间隔值
+	GetDurationE(key string) (time.Duration, error)
+
+	// GetStringSliceE 获取字符串切片
+	GetStringSliceE(key string) ([]string, error)
+
+	// GetStringMapE 获取字符串映射
+	GetStringMapE(key string) (map[string]string, error)
+
+	// Sub 获取子配置
+	// 返回 prefix 下的所有配置项作为新的 Config，key 相对于 prefix
+	// 例如 Sub("database").GetString("host", "") 等价于 GetString("database.host", "")
+	Sub(prefix string) Config
+
+	// Unmarshal 将 key 前缀下的配置反序列化到结构体
+	// key 为空时解码整个配置，字段名通过 mapstructure 标签指定
+	Unmarshal(key string, out any) error
+
+	// UnmarshalKey 将指定 key 的配置反序列化到结构体
+	// key 不存在时返回 ErrKeyNotFound
+	UnmarshalKey(key string, out any) error
+
+	// AllSettings 将所有扁平化的配置键重建为嵌套 map
+	AllSettings() map[string]any
+
 	// Keys 返回所有配置键
 	Keys() []string
 
@@ -128,6 +187,9 @@ type Config interface {
 // configImpl Config 接口的实现
 type configImpl struct {
 	data map[string]Value
+
+	parentsOnce sync.Once
+	parents     map[string]bool // 拥有 key.* 子 key 的路径，首次使用时计算
 }
 
 // newConfigImpl 创建配置实例
@@ -192,19 +254,171 @@ func (c *configImpl) GetDuration(key string, defaultVal time.Duration) time.Dura
 }
 
 func (c *configImpl) GetStringSlice(key string, defaultVal []string) []string {
+	if list, ok := c.listFromTree(key); ok {
+		return list
+	}
 	if val, ok := c.data[key]; ok {
 		return val.StringSlice(defaultVal)
 	}
 	return defaultVal
 }
 
+// listFromTree 由 key.0、key.1 等下标子 key 重建字符串切片
+// 下标子 key 可能被高优先级配置源逐个覆盖，因此优先于 key 本身的原始数组
+func (c *configImpl) listFromTree(key string) ([]string, bool) {
+	if !c.hasChildren(key) {
+		return nil, false
+	}
+	list, ok := restoreLists(buildTree(c.data, key)).([]any)
+	if !ok {
+		return nil, false
+	}
+	result := make([]string, len(list))
+	for i, item := range list {
+		result[i] = NewValueFromInterface(item).String()
+	}
+	return result, true
+}
+
 func (c *configImpl) GetStringMap(key string, defaultVal map[string]string) map[string]string {
 	if val, ok := c.data[key]; ok {
 		return val.StringMap(defaultVal)
 	}
+	if m := c.stringMapFromTree(key); m != nil {
+		return m
+	}
 	return defaultVal
 }
 
+// stringMapFromTree 由 key 前缀下的子 key 重建字符串映射，不存在子 key 时返回 nil
+func (c *configImpl) stringMapFromTree(key string) map[string]string {
+	if !c.hasChildren(key) {
+		return nil
+	}
+	tree := buildTree(c.data, key)
+	if len(tree) == 0 {
+		return nil
+	}
+	result := make(map[string]string)
+	flattenTree("", tree, result)
+	return result
+}
+
+// hasChildren 判断是否存在 key.* 子 key
+// 子 key 索引只在首次调用时构建，之后的查询不再遍历整个配置
+func (c *configImpl) hasChildren(key string) bool {
+	if key == "" {
+		return len(c.data) > 0
+	}
+	c.parentsOnce.Do(func() {
+		c.parents = make(map[string]bool)
+		for k := range c.data {
+			for i := strings.LastIndexByte(k, '.'); i > 0; i = strings.LastIndexByte(k[:i], '.') {
+				c.parents[k[:i]] = true
+			}
+		}
+	})
+	return c.parents[key]
+}
+
+func (c *configImpl) GetStringE(key string) (string, error) {
+	val, ok := c.data[key]
+	if !ok {
+		return "", &KeyError{Key: key, Err: ErrKeyNotFound}
+	}
+	return val.String(), nil
+}
+
+func (c *configImpl) GetIntE(key string) (int, error) {
+	return getE(c, key, Value.IntE)
+}
+
+func (c *configImpl) GetInt64E(key string) (int64, error) {
+	return getE(c, key, Value.Int64E)
+}
+
+func (c *configImpl) GetFloat64E(key string) (float64, error) {
+	return getE(c, key, Value.Float64E)
+}
+
+func (c *configImpl) GetBoolE(key string) (bool, error) {
+	return getE(c, key, Value.BoolE)
+}
+
+func (c *configImpl) GetDurationE(key string) (time.Duration, error) {
+	return getE(c, key, Value.DurationE)
+}
+
+func (c *configImpl) GetStringSliceE(key string) ([]string, error) {
+	if list, ok := c.listFromTree(key); ok {
+		return list, nil
+	}
+	return getE(c, key, Value.StringSliceE)
+}
+
+func (c *configImpl) GetStringMapE(key string) (map[string]string, error) {
+	if _, ok := c.data[key]; !ok {
+		if m := c.stringMapFromTree(key); m != nil {
+			return m, nil
+		}
+	}
+	return getE(c, key, Value.StringMapE)
+}
+
+// getE 查找 key 并执行转换，将错误包装为 *KeyError
+func getE[T any](c *configImpl, key string, convert func(Value) (T, error)) (T, error) {
+	var zero T
+	val, ok := c.data[key]
+	if !ok {
+		return zero, &KeyError{Key: key, Err: ErrKeyNotFound}
+	}
+	result, err := convert(val)
+	if err != nil {
+		return zero, &KeyError{Key: key, Value: errorValue(val), Err: err}
+	}
+	return result, nil
+}
+
+func (c *configImpl) Sub(prefix string) Config {
+	prefix = strings.Trim(prefix, ".")
+	if prefix == "" {
+		return c.clone()
+	}
+
+	data := make(map[string]Value)
+	// prefix 本身存储的是嵌套 map 时，展开为子配置
+	if val, ok := c.data[prefix]; ok {
+		if nested, ok := nestedMap(val.Raw()); ok {
+			flattenMap("", nested, val.Sensitive(), data)
+		}
+	}
+
+	p := prefix + "."
+	for k, v := range c.data {
+		if strings.HasPrefix(k, p) {
+			data[k[len(p):]] = v
+		}
+	}
+
+	return newConfigImplFromMap(data)
+}
+
+func (c *configImpl) Unmarshal(key string, out any) error {
+	return newDecoder(c.data).unmarshal(key, out)
+}
+
+func (c *configImpl) UnmarshalKey(key string, out any) error {
+	d := newDecoder(c.data)
+	if key == "" || !d.exists(key) {
+		return &KeyError{Key: key, Err: ErrKeyNotFound}
+	}
+	return d.unmarshal(key, out)
+}
+
+func (c *configImpl) AllSettings() map[string]any {
+	return buildTree(c.data, "")
+}
+
 func (c *configImpl) Keys() []string {
 	keys := make([]string, 0, len(c.data))
 	for k := range c.data {
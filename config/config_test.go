@@ -1,6 +1,8 @@
 package config
 
 import (
+	"errors"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -207,6 +209,133 @@ func TestConfigImpl_GetStringMap(t *testing.T) {
 	}
 }
 
+func TestConfigImpl_GetStringMap_FromFlattenedKeys(t *testing.T) {
+	cfg := newConfigImplFromMap(map[string]Value{
+		"app.labels.team":     NewValue("infra"),
+		"app.labels.env":      NewValue("prod"),
+		"app.labels.tier.web": NewValueFromInterface(1),
+		"app.name":            NewValue("svc"),
+	})
+
+	got := cfg.GetStringMap("app.labels", nil)
+	want := map[string]string{"team": "infra", "env": "prod", "tier.web": "1"}
+	if len(got) != len(want) {
+		t.Fatalf("GetStringMap() = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("GetStringMap()[%s] = %v, want %v", k, got[k], v)
+		}
+	}
+
+	if m, err := cfg.GetStringMapE("app.labels"); err != nil || m["team"] != "infra" {
+		t.Errorf("GetStringMapE() = %v, %v", m, err)
+	}
+	if got := cfg.GetStringMap("app.missing", map[string]string{"d": "v"}); got["d"] != "v" {
+		t.Errorf("GetStringMap() should return default for missing prefix, got %v", got)
+	}
+}
+
+func TestConfigImpl_AllSettings(t *testing.T) {
+	cfg := newConfigImplFromMap(map[string]Value{
+		"database.host":      NewValue("localhost"),
+		"database.port":      NewValueFromInterface(5432),
+		"database.pool.max":  NewValueFromInterface(10),
+		"debug":              NewValueFromInterface(true),
+		"cache":              NewValueFromInterface(map[string]any{"ttl": "1m"}),
+		"cache.host":         NewValue("redis"),
+		"server":             NewValue("shadowed"),
+		"server.listen.port": NewValue("8080"),
+	})
+
+	all := cfg.AllSettings()
+
+	db, ok := all["database"].(map[string]any)
+	if !ok {
+		t.Fatalf("AllSettings()[database] = %T, want map", all["database"])
+	}
+	if db["host"] != "localhost" || db["port"] != 5432 {
+		t.Errorf("database = %v", db)
+	}
+	if pool, ok := db["pool"].(map[string]any); !ok || pool["max"] != 10 {
+		t.Errorf("database.pool = %v", db["pool"])
+	}
+	if all["debug"] != true {
+		t.Errorf("debug = %v", all["debug"])
+	}
+
+	cache, ok := all["cache"].(map[string]any)
+	if !ok || cache["ttl"] != "1m" || cache["host"] != "redis" {
+		t.Errorf("cache = %v, want raw map merged with flattened keys", all["cache"])
+	}
+
+	if _, ok := all["server"].(map[string]any); !ok {
+		t.Errorf("server = %v, nested keys should win over leaf value", all["server"])
+	}
+}
+
+func TestConfigImpl_IndexedLists(t *testing.T) {
+	cfg := newConfigImplFromMap(map[string]Value{
+		"servers": NewValueFromInterface([]any{
+			map[string]any{"host": "a", "port": 1},
+			map[string]any{"host": "b", "port": 2},
+		}),
+		"servers.0.host": NewValue("override"), // 模拟高优先级配置源覆盖单个元素
+		"servers.0.port": NewValueFromInterface(1),
+		"servers.1.host": NewValue("b"),
+		"servers.1.port": NewValueFromInterface(2),
+		"tags":           NewValueFromInterface([]any{"x", "y"}),
+		"tags.0":         NewValue("x"),
+		"tags.1":         NewValue("z"),
+		"sparse.0":       NewValue("a"),
+		"sparse.5":       NewValue("b"),
+	})
+
+	t.Run("string_slice", func(t *testing.T) {
+		got := cfg.GetStringSlice("tags", nil)
+		if len(got) != 2 || got[1] != "z" {
+			t.Errorf("GetStringSlice() = %v, want [x z]", got)
+		}
+	})
+
+	t.Run("map_slice", func(t *testing.T) {
+		got, err := Get[[]map[string]any](cfg, "servers")
+		if err != nil {
+			t.Fatalf("Get[[]map[string]any]() error = %v", err)
+		}
+		if len(got) != 2 || got[0]["host"] != "override" || got[1]["port"] != 2 {
+			t.Errorf("Get[[]map[string]any]() = %v", got)
+		}
+	})
+
+	t.Run("struct_slice", func(t *testing.T) {
+		var servers []struct {
+			Host string `mapstructure:"host"`
+			Port int    `mapstructure:"port"`
+		}
+		if err := cfg.UnmarshalKey("servers", &servers); err != nil {
+			t.Fatalf("UnmarshalKey() error = %v", err)
+		}
+		if len(servers) != 2 || servers[0].Host != "override" || servers[1].Port != 2 {
+			t.Errorf("UnmarshalKey() = %+v", servers)
+		}
+	})
+
+	t.Run("all_settings", func(t *testing.T) {
+		all := cfg.AllSettings()
+		servers, ok := all["servers"].([]any)
+		if !ok || len(servers) != 2 {
+			t.Fatalf("AllSettings()[servers] = %v, want list", all["servers"])
+		}
+		if first, _ := servers[0].(map[string]any); first["host"] != "override" {
+			t.Errorf("servers[0] = %v", servers[0])
+		}
+		if _, ok := all["sparse"].(map[string]any); !ok {
+			t.Errorf("sparse indices should stay a map, got %T", all["sparse"])
+		}
+	})
+}
+
 func TestConfigImpl_Keys(t *testing.T) {
 	cfg := newConfigImplFromMap(map[string]Value{
 		"key1": NewValue("val1"),
@@ -246,6 +375,139 @@ func TestConfigImpl_Has(t *testing.T) {
 	}
 }
 
+func TestConfigImpl_StrictGetters(t *testing.T) {
+	cfg := newConfigImplFromMap(map[string]Value{
+		"server.port":    NewValue("8080"),
+		"server.timeout": NewValue("30 s"),
+		"server.name":    NewValue("api"),
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		port, err := cfg.GetIntE("server.port")
+		if err != nil || port != 8080 {
+			t.Errorf("GetIntE() = %v, %v", port, err)
+		}
+		name, err := cfg.GetStringE("server.name")
+		if err != nil || name != "api" {
+			t.Errorf("GetStringE() = %v, %v", name, err)
+		}
+	})
+
+	t.Run("type_mismatch", func(t *testing.T) {
+		_, err := cfg.GetDurationE("server.timeout")
+		if !errors.Is(err, ErrTypeMismatch) {
+			t.Fatalf("GetDurationE() error = %v, want ErrTypeMismatch", err)
+		}
+		var keyErr *KeyError
+		if !errors.As(err, &keyErr) {
+			t.Fatalf("GetDurationE() error should be *KeyError, got %T", err)
+		}
+		if keyErr.Key != "server.timeout" || keyErr.Value != "30 s" {
+			t.Errorf("KeyError = %+v", keyErr)
+		}
+		if !strings.Contains(err.Error(), "server.timeout") || !strings.Contains(err.Error(), "30 s") {
+			t.Errorf("error %q should mention key and raw value", err)
+		}
+	})
+
+	t.Run("key_not_found", func(t *testing.T) {
+		getters := map[string]func() error{
+			"GetStringE":      func() error { _, err := cfg.GetStringE("missing"); return err },
+			"GetIntE":         func() error { _, err := cfg.GetIntE("missing"); return err },
+			"GetInt64E":       func() error { _, err := cfg.GetInt64E("missing"); return err },
+			"GetFloat64E":     func() error { _, err := cfg.GetFloat64E("missing"); return err },
+			"GetBoolE":        func() error { _, err := cfg.GetBoolE("missing"); return err },
+			"GetDurationE":    func() error { _, err := cfg.GetDurationE("missing"); return err },
+			"GetStringSliceE": func() error { _, err := cfg.GetStringSliceE("missing"); return err },
+			"GetStringMapE":   func() error { _, err := cfg.GetStringMapE("missing"); return err },
+		}
+		for name, get := range getters {
+			if err := get(); !errors.Is(err, ErrKeyNotFound) {
+				t.Errorf("%s() error = %v, want ErrKeyNotFound", name, err)
+			}
+		}
+	})
+}
+
+func TestConfigImpl_Sub(t *testing.T) {
+	cfg := newConfigImplFromMap(map[string]Value{
+		"database.host":         NewValue("localhost"),
+		"database.port":         NewValue("5432"),
+		"database.pool.max":     NewValueFromInterface(10),
+		"databases.legacy.host": NewValue("legacy"),
+		"server.port":           NewValue("8080"),
+		"cache":                 NewValueFromInterface(map[string]any{"host": "redis", "ttl": "1m"}),
+	})
+
+	t.Run("prefix", func(t *testing.T) {
+		sub := cfg.Sub("database")
+		if got := sub.GetString("host", ""); got != "localhost" {
+			t.Errorf("GetString(host) = %v, want localhost", got)
+		}
+		if got := sub.GetInt("port", 0); got != 5432 {
+			t.Errorf("GetInt(port) = %v, want 5432", got)
+		}
+		if !sub.Has("pool.max") {
+			t.Error("Has(pool.max) should be true")
+		}
+		if sub.Has("server.port") || sub.Has("legacy.host") {
+			t.Error("Sub() should not leak keys outside the prefix")
+		}
+		if len(sub.Keys()) != 3 {
+			t.Errorf("Keys() = %v, want 3 keys", sub.Keys())
+		}
+	})
+
+	t.Run("nested_prefix", func(t *testing.T) {
+		sub := cfg.Sub("database").Sub("pool")
+		if got := sub.GetInt("max", 0); got != 10 {
+			t.Errorf("GetInt(max) = %v, want 10", got)
+		}
+		if got := cfg.Sub("database.pool").GetInt("max", 0); got != 10 {
+			t.Errorf("Sub(database.pool).GetInt(max) = %v, want 10", got)
+		}
+	})
+
+	t.Run("raw_map_value", func(t *testing.T) {
+		sub := cfg.Sub("cache")
+		if got := sub.GetString("host", ""); got != "redis" {
+			t.Errorf("GetString(host) = %v, want redis", got)
+		}
+		if got := sub.GetDuration("ttl", 0); got != time.Minute {
+			t.Errorf("GetDuration(ttl) = %v, want 1m", got)
+		}
+	})
+
+	t.Run("missing_prefix", func(t *testing.T) {
+		sub := cfg.Sub("missing")
+		if sub == nil {
+			t.Fatal("Sub() should never return nil")
+		}
+		if len(sub.Keys()) != 0 {
+			t.Errorf("Keys() = %v, want empty", sub.Keys())
+		}
+	})
+
+	t.Run("empty_prefix", func(t *testing.T) {
+		if got := len(cfg.Sub("").Keys()); got != len(cfg.Keys()) {
+			t.Errorf("Sub(\"\") has %d keys, want %d", got, len(cfg.Keys()))
+		}
+	})
+
+	t.Run("unmarshal", func(t *testing.T) {
+		var db struct {
+			Host string `mapstructure:"host"`
+			Port int    `mapstructure:"port"`
+		}
+		if err := cfg.Sub("database").Unmarshal("", &db); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if db.Host != "localhost" || db.Port != 5432 {
+			t.Errorf("Unmarshal() = %+v", db)
+		}
+	})
+}
+
 func TestConfigImpl_EmptyConfig(t *testing.T) {
 	cfg := newConfigImpl()
 
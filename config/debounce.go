@@ -0,0 +1,94 @@
+package config
+
+import (
+	"slices"
+	"time"
+)
+
+// defaultMaxWaitFactor 未设置最长等待时间时，按防抖窗口的倍数计算
+const defaultMaxWaitFactor = 10
+
+// sourceEvent 带配置源名称的变更事件
+type sourceEvent struct {
+	source string
+	event  Event
+}
+
+// debounceLoop 合并一段时间内来自所有配置源的变更事件，只触发一次重载
+// 每个新事件将重载推迟 window；从批次第一个事件起超过 maxWait 时立即重载，避免持续变更时重载一直被推迟
+func (m *Manager) debounceLoop(window, maxWait time.Duration) {
+	defer m.wg.Done()
+
+	timer := time.NewTimer(window)
+	timer.Stop()
+	defer timer.Stop()
+
+	var (
+		batch    []sourceEvent
+		deadline time.Time
+	)
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case se := <-m.pending:
+			if len(batch) == 0 {
+				deadline = time.Now().Add(maxWait)
+			}
+			batch = append(batch, se)
+			timer.Reset(min(window, max(time.Until(deadline), 0)))
+		case <-timer.C:
+			if len(batch) == 0 {
+				continue
+			}
+			sources, event := coalesceEvents(batch)
+			batch = nil
+			m.reload(sources, event)
+		}
+	}
+}
+
+// coalesceEvents 将一批事件合并为一个事件，返回去重后的配置源名称
+// Keys 取并集（任一事件未指明 Keys 时为空），Timestamp 取最新，实际变更类型由重载后的 diff 决定
+// 来自多个配置源时合并事件的 Source 为空，不拼接名称，避免与真实的配置源名称混淆
+func coalesceEvents(batch []sourceEvent) ([]string, Event) {
+	var (
+		sources []string
+		keys    []string
+		latest  time.Time
+		whole   bool // 存在未指明 Keys 的事件，需要整体重载
+	)
+	for _, se := range batch {
+		if !slices.Contains(sources, se.source) {
+			sources = append(sources, se.source)
+		}
+		if len(se.event.Keys) == 0 {
+			whole = true
+		}
+		for _, key := range se.event.Keys {
+			if !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+		if se.event.Timestamp.After(latest) {
+			latest = se.event.Timestamp
+		}
+	}
+	if len(batch) == 1 {
+		return sources, batch[0].event
+	}
+	if whole {
+		keys = nil
+	}
+
+	source := ""
+	if len(sources) == 1 {
+		source = sources[0]
+	}
+	return sources, Event{
+		Type:      EventTypeUpdate,
+		Source:    source,
+		Keys:      keys,
+		Timestamp: latest,
+	}
+}
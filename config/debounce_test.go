@@ -0,0 +1,182 @@
+package config
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCoalesceEvents(t *testing.T) {
+	t0 := time.Unix(100, 0)
+
+	tests := []struct {
+		name        string
+		batch       []sourceEvent
+		wantSources []string
+		wantSource  string
+		wantKeys    []string
+		wantTime    time.Time
+	}{
+		{
+			name:        "single_event_unchanged",
+			batch:       []sourceEvent{{"file", Event{Type: EventTypeCreate, Source: "file", Keys: []string{"a"}, Timestamp: t0}}},
+			wantSources: []string{"file"},
+			wantSource:  "file",
+			wantKeys:    []string{"a"},
+			wantTime:    t0,
+		},
+		{
+			name: "same_source",
+			batch: []sourceEvent{
+				{"file", Event{Keys: []string{"a"}, Timestamp: t0}},
+				{"file", Event{Keys: []string{"a", "b"}, Timestamp: t0.Add(time.Second)}},
+			},
+			wantSources: []string{"file"},
+			wantSource:  "file",
+			wantKeys:    []string{"a", "b"},
+			wantTime:    t0.Add(time.Second),
+		},
+		{
+			name: "multiple_sources_whole_reload",
+			batch: []sourceEvent{
+				{"file", Event{Keys: []string{"a"}, Timestamp: t0.Add(time.Second)}},
+				{"consul", Event{Timestamp: t0}},
+			},
+			wantSources: []string{"file", "consul"},
+			wantSource:  "",
+			wantKeys:    nil,
+			wantTime:    t0.Add(time.Second),
+		},
+		{
+			name: "name_with_comma",
+			batch: []sourceEvent{
+				{"file:/etc/a,b.yaml", Event{Timestamp: t0}},
+				{"consul", Event{Timestamp: t0}},
+			},
+			wantSources: []string{"file:/etc/a,b.yaml", "consul"},
+			wantSource:  "",
+			wantTime:    t0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sources, event := coalesceEvents(tt.batch)
+			if !slices.Equal(sources, tt.wantSources) {
+				t.Errorf("sources = %q, want %q", sources, tt.wantSources)
+			}
+			if event.Source != tt.wantSource {
+				t.Errorf("event.Source = %q, want %q", event.Source, tt.wantSource)
+			}
+			if len(event.Keys) != len(tt.wantKeys) {
+				t.Fatalf("Keys = %v, want %v", event.Keys, tt.wantKeys)
+			}
+			for i, key := range tt.wantKeys {
+				if event.Keys[i] != key {
+					t.Errorf("Keys = %v, want %v", event.Keys, tt.wantKeys)
+				}
+			}
+			if !event.Timestamp.Equal(tt.wantTime) {
+				t.Errorf("Timestamp = %v, want %v", event.Timestamp, tt.wantTime)
+			}
+		})
+	}
+}
+
+func TestManager_WithDebounce(t *testing.T) {
+	setup := func(t *testing.T, opts ...ManagerOption) (*Manager, *countingSource, []*mockWatcher, chan Event) {
+		t.Helper()
+		watchers := []*mockWatcher{{eventCh: make(chan Event, 100)}, {eventCh: make(chan Event, 100)}}
+		file := &countingSource{mockSource: &mockSource{
+			name:    "file",
+			data:    map[string]Value{"a": NewValue("1")},
+			watcher: watchers[0],
+		}}
+		consul := &mockSource{
+			name:     "consul",
+			priority: 80,
+			data:     map[string]Value{"b": NewValue("2")},
+			watcher:  watchers[1],
+		}
+
+		m := NewManager(opts...)
+		m.AddSource(file, consul)
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		events := make(chan Event, 100)
+		m.OnChange(func(event Event, oldConfig, newConfig Config) { events <- event })
+		if err := m.Watch(); err != nil {
+			t.Fatalf("Watch() error = %v", err)
+		}
+		t.Cleanup(func() { m.Close() })
+		return m, file, watchers, events
+	}
+
+	t.Run("burst_single_reload", func(t *testing.T) {
+		_, file, watchers, events := setup(t, WithDebounce(50*time.Millisecond, time.Second))
+
+		for i := 0; i < 5; i++ {
+			watchers[0].eventCh <- Event{Type: EventTypeUpdate, Source: "file"}
+			watchers[1].eventCh <- Event{Type: EventTypeUpdate, Source: "consul"}
+		}
+
+		select {
+		case event := <-events:
+			if event.Source != "" {
+				t.Errorf("Source = %q, want empty for events from several sources", event.Source)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for callback")
+		}
+
+		select {
+		case event := <-events:
+			t.Errorf("unexpected second callback: %+v", event)
+		case <-time.After(150 * time.Millisecond):
+		}
+		if got := file.loads.Load(); got != 2 {
+			t.Errorf("Load() called %d times, want 2 (initial load and one reload)", got)
+		}
+	})
+
+	t.Run("max_wait", func(t *testing.T) {
+		_, _, watchers, events := setup(t, WithDebounce(50*time.Millisecond, 100*time.Millisecond))
+
+		// 事件间隔小于防抖窗口，只能依靠最长等待时间触发重载
+		stop := time.After(400 * time.Millisecond)
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+	loop:
+		for {
+			select {
+			case <-ticker.C:
+				watchers[0].eventCh <- Event{Type: EventTypeUpdate, Source: "file"}
+			case <-events:
+				break loop
+			case <-stop:
+				t.Fatal("continuous events should not stall reload beyond max wait")
+			}
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		_, file, watchers, events := setup(t)
+
+		for i := 0; i < 3; i++ {
+			watchers[0].eventCh <- Event{Type: EventTypeUpdate, Source: "file"}
+		}
+		for i := 0; i < 3; i++ {
+			select {
+			case <-events:
+			case <-time.After(time.Second):
+				t.Fatal("timeout waiting for callback")
+			}
+		}
+		if got := file.loads.Load(); got != 4 {
+			t.Errorf("Load() called %d times, want 4", got)
+		}
+	})
+}
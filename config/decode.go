@@ -0,0 +1,458 @@
+package config
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// tagName 结构体字段标签名，与 ARCHITECTURE.md 中的示例保持一致
+const tagName = "mapstructure"
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// decoder 将扁平化的配置映射解码到 Go 结构体
+// 嵌套结构通过点分隔的 key 前缀定位，key 匹配不区分大小写
+type decoder struct {
+	data  map[string]Value
+	index map[string]string // 小写 key -> 原始 key
+}
+
+func newDecoder(data map[string]Value) *decoder {
+	index := make(map[string]string, len(data))
+	for k := range data {
+		index[strings.ToLower(k)] = k
+	}
+	return &decoder{data: data, index: index}
+}
+
+// unmarshal 将 key 前缀下的配置解码到 out，key 为空表示整个配置
+func (d *decoder) unmarshal(key string, out any) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("config: unmarshal target must be a non-nil pointer, got %T", out)
+	}
+	return d.decode(key, rv.Elem())
+}
+
+// lookup 查找精确匹配的 key
+func (d *decoder) lookup(key string) (Value, bool) {
+	if key == "" {
+		return Value{}, false
+	}
+	if val, ok := d.data[key]; ok {
+		return val, true
+	}
+	if orig, ok := d.index[strings.ToLower(key)]; ok {
+		return d.data[orig], true
+	}
+	return Value{}, false
+}
+
+// exists 检查 key 本身或其下的子 key 是否存在
+func (d *decoder) exists(key string) bool {
+	if key == "" {
+		return len(d.data) > 0
+	}
+	if _, ok := d.lookup(key); ok {
+		return true
+	}
+	return len(d.children(key)) > 0
+}
+
+// children 返回 key 前缀下的直接子级名称（保留原始大小写）
+func (d *decoder) children(key string) []string {
+	prefix := ""
+	if key != "" {
+		prefix = strings.ToLower(key) + "."
+	}
+
+	seen := make(map[string]bool)
+	result := make([]string, 0)
+	for k := range d.data {
+		if len(k) <= len(prefix) || strings.ToLower(k[:len(prefix)]) != prefix {
+			continue
+		}
+		child := k[len(prefix):]
+		if i := strings.Index(child, "."); i >= 0 {
+			child = child[:i]
+		}
+		if !seen[child] {
+			seen[child] = true
+			result = append(result, child)
+		}
+	}
+	return result
+}
+
+// decode 根据目标类型从 key 位置解码
+func (d *decoder) decode(key string, rv reflect.Value) error {
+	// 下标子 key（key.0、key.1）可能被逐个覆盖，优先于 key 本身的原始数组
+	switch rv.Kind() {
+	case reflect.Slice:
+		children := d.children(key)
+		if indices, size, ok := listIndices(children); ok {
+			return d.decodeList(key, children, indices, size, rv)
+		}
+	case reflect.Interface:
+		if rv.NumMethod() == 0 && len(d.children(key)) > 0 {
+			rv.Set(reflect.ValueOf(d.decodeAny(key)))
+			return nil
+		}
+	}
+
+	if val, ok := d.lookup(key); ok {
+		if err := decodeValue(val, rv); err != nil {
+			return &KeyError{Key: key, Value: errorValue(val), Err: err}
+		}
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if !d.exists(key) {
+			return nil
+		}
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return d.decode(key, rv.Elem())
+	case reflect.Struct:
+		if reflect.PointerTo(rv.Type()).Implements(textUnmarshalerType) {
+			return nil
+		}
+		return d.decodeStruct(key, rv)
+	case reflect.Map:
+		return d.decodeMap(key, rv)
+	default:
+		return nil
+	}
+}
+
+// decodeList 将下标子 key 解码为切片
+func (d *decoder) decodeList(key string, children []string, indices []int, size int, rv reflect.Value) error {
+	slice := reflect.MakeSlice(rv.Type(), size, size)
+
+	var errs []error
+	for i, child := range children {
+		if err := d.decode(joinKey(key, child), slice.Index(indices[i])); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	rv.Set(slice)
+	return nil
+}
+
+// decodeAny 将 key 位置的配置还原为 map[string]any、[]any 或原始值
+func (d *decoder) decodeAny(key string) any {
+	children := d.children(key)
+	if len(children) == 0 {
+		val, _ := d.lookup(key)
+		return val.Raw()
+	}
+
+	if indices, size, ok := listIndices(children); ok {
+		list := make([]any, size)
+		for i, child := range children {
+			list[indices[i]] = d.decodeAny(joinKey(key, child))
+		}
+		return list
+	}
+
+	m := make(map[string]any, len(children))
+	for _, child := range children {
+		m[child] = d.decodeAny(joinKey(key, child))
+	}
+	return m
+}
+
+// decodeStruct 逐字段解码结构体
+func (d *decoder) decodeStruct(key string, rv reflect.Value) error {
+	var errs []error
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		name, squash := parseTag(field)
+		if name == "-" {
+			continue
+		}
+
+		// 嵌入结构体默认展开到当前层级
+		// 未导出的嵌入结构体本身不可设置，但其导出字段仍可写入
+		fv := rv.Field(i)
+		if !fv.CanSet() && !(squash && fv.Kind() == reflect.Struct) {
+			continue
+		}
+
+		fieldKey := joinKey(key, name)
+		if squash {
+			fieldKey = key
+		}
+		if err := d.decode(fieldKey, fv); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// decodeMap 将 key 前缀下的子级解码为 map
+func (d *decoder) decodeMap(key string, rv reflect.Value) error {
+	rt := rv.Type()
+	if rt.Key().Kind() != reflect.String {
+		return &KeyError{Key: key, Err: fmt.Errorf("%w: unsupported map key type %s", ErrTypeMismatch, rt.Key())}
+	}
+
+	children := d.children(key)
+	if len(children) == 0 {
+		return nil
+	}
+	if rv.IsNil() {
+		rv.Set(reflect.MakeMapWithSize(rt, len(children)))
+	}
+
+	var errs []error
+	for _, child := range children {
+		elem := reflect.New(rt.Elem()).Elem()
+		if err := d.decode(joinKey(key, child), elem); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		rv.SetMapIndex(reflect.ValueOf(child).Convert(rt.Key()), elem)
+	}
+
+	return errors.Join(errs...)
+}
+
+// parseTag 解析字段标签，返回配置名称以及是否展开到父级
+func parseTag(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get(tagName)
+	name, opts, _ := strings.Cut(tag, ",")
+	squash := strings.Contains(","+opts+",", ",squash,")
+
+	if name == "" {
+		if field.Anonymous && !squash {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			squash = ft.Kind() == reflect.Struct
+		}
+		name = strings.ToLower(field.Name)
+	}
+	return name, squash
+}
+
+// joinKey 拼接点分隔的 key
+func joinKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// decodeValue 将单个值转换为目标类型
+// 敏感标记随值传递，转换错误中不包含敏感内容
+func decodeValue(val Value, rv reflect.Value) error {
+	raw := val.Raw()
+	if raw == nil {
+		return nil
+	}
+
+	if rv.Kind() != reflect.Pointer && rv.CanAddr() {
+		if u, ok := rv.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			if s, ok := raw.(string); ok {
+				err := u.UnmarshalText([]byte(s))
+				// 第三方实现的错误可能包含输入内容
+				if err != nil && val.Sensitive() {
+					return val.mismatch(rv.Type().String())
+				}
+				return err
+			}
+		}
+	}
+
+	if rv.Type() == durationType {
+		d, err := val.DurationE()
+		if err != nil {
+			return err
+		}
+		rv.SetInt(int64(d))
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return decodeValue(val, rv.Elem())
+
+	case reflect.Interface:
+		if !reflect.TypeOf(raw).AssignableTo(rv.Type()) {
+			return fmt.Errorf("%w: cannot assign %T to %s", ErrTypeMismatch, raw, rv.Type())
+		}
+		rv.Set(reflect.ValueOf(raw))
+		return nil
+
+	case reflect.String:
+		rv.SetString(val.String())
+		return nil
+
+	case reflect.Bool:
+		b, err := val.BoolE()
+		if err != nil {
+			return err
+		}
+		rv.SetBool(b)
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := val.Int64E()
+		if err != nil {
+			return err
+		}
+		if rv.OverflowInt(i) {
+			return fmt.Errorf("%w: %v overflows %s", ErrTypeMismatch, val, rv.Type())
+		}
+		rv.SetInt(i)
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := val.Int64E()
+		if err != nil {
+			return err
+		}
+		if i < 0 || rv.OverflowUint(uint64(i)) {
+			return fmt.Errorf("%w: %v overflows %s", ErrTypeMismatch, val, rv.Type())
+		}
+		rv.SetUint(uint64(i))
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		f, err := val.Float64E()
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(f)
+		return nil
+
+	case reflect.Slice:
+		return decodeSlice(val, rv)
+
+	case reflect.Map, reflect.Struct:
+		m, err := toMap(raw)
+		if err != nil {
+			return val.mismatch("map")
+		}
+		// 嵌套 map 原始值：展开后交给 decoder 按层级处理
+		flat := make(map[string]Value)
+		flattenMap("", m, val.Sensitive(), flat)
+		return newDecoder(flat).decode("", rv)
+
+	default:
+		return fmt.Errorf("%w: unsupported target type %s", ErrTypeMismatch, rv.Type())
+	}
+}
+
+// decodeSlice 将数组、JSON 数组字符串或逗号分隔字符串解码为切片
+func decodeSlice(v Value, rv reflect.Value) error {
+	var items []any
+
+	switch val := v.Raw().(type) {
+	case []any:
+		items = val
+	case []string:
+		items = make([]any, len(val))
+		for i, s := range val {
+			items[i] = s
+		}
+	case string:
+		if err := json.Unmarshal([]byte(val), &items); err != nil {
+			items = nil
+			for _, p := range strings.Split(val, ",") {
+				if trimmed := strings.TrimSpace(p); trimmed != "" {
+					items = append(items, trimmed)
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("%w: cannot convert %T to %s", ErrTypeMismatch, val, rv.Type())
+	}
+
+	slice := reflect.MakeSlice(rv.Type(), len(items), len(items))
+	for i, item := range items {
+		if err := decodeValue(Value{raw: item, sensitive: v.Sensitive()}, slice.Index(i)); err != nil {
+			return fmt.Errorf("index %d: %w", i, err)
+		}
+	}
+	rv.Set(slice)
+	return nil
+}
+
+// flattenMap 将嵌套 map 扁平化为点分隔的 key
+// sensitive 为 true 时展开后的每个值都标记为敏感，与整体标记为敏感的原始值保持一致
+func flattenMap(prefix string, data map[string]any, sensitive bool, result map[string]Value) {
+	for k, v := range data {
+		key := joinKey(prefix, k)
+		if nested, ok := nestedMap(v); ok {
+			flattenMap(key, nested, sensitive, result)
+			continue
+		}
+		result[key] = Value{raw: v, sensitive: sensitive}
+	}
+}
+
+// nestedMap 判断原始值是否为嵌套 map（不包含 JSON 字符串）
+func nestedMap(raw any) (map[string]any, bool) {
+	switch raw.(type) {
+	case map[string]any, map[any]any, map[string]string:
+		m, _ := toMap(raw)
+		return m, true
+	default:
+		return nil, false
+	}
+}
+
+func toMap(raw any) (map[string]any, error) {
+	switch val := raw.(type) {
+	case map[string]any:
+		return val, nil
+	case map[any]any:
+		result := make(map[string]any, len(val))
+		for k, v := range val {
+			result[fmt.Sprintf("%v", k)] = v
+		}
+		return result, nil
+	case map[string]string:
+		result := make(map[string]any, len(val))
+		for k, v := range val {
+			result[k] = v
+		}
+		return result, nil
+	case string:
+		var m map[string]any
+		if err := json.Unmarshal([]byte(val), &m); err != nil {
+			return nil, fmt.Errorf("%w: cannot convert %q to map", ErrTypeMismatch, val)
+		}
+		return m, nil
+	default:
+		return nil, fmt.Errorf("%w: cannot convert %T to map", ErrTypeMismatch, raw)
+	}
+}
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testDatabaseConfig struct {
+	Host     string        `mapstructure:"host"`
+	Port     int           `mapstructure:"port"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+	Replicas []string      `mapstructure:"replicas"`
+}
+
+type testBaseConfig struct {
+	Name  string `mapstructure:"name"`
+	Debug bool   `mapstructure:"debug"`
+}
+
+type testAppConfig struct {
+	testBaseConfig
+	Database testDatabaseConfig  `mapstructure:"database"`
+	Cache    *testDatabaseConfig `mapstructure:"cache"`
+	Missing  *testDatabaseConfig `mapstructure:"missing"`
+	Labels   map[string]string   `mapstructure:"labels"`
+	Weights  []float64           `mapstructure:"weights"`
+	Ignored  string              `mapstructure:"-"`
+	Version  uint16
+}
+
+func TestConfigImpl_Unmarshal(t *testing.T) {
+	cfg := newConfigImplFromMap(map[string]Value{
+		"name":              NewValue("app"),
+		"debug":             NewValue("true"),
+		"database.host":     NewValue("localhost"),
+		"database.port":     NewValueFromInterface(float64(5432)),
+		"database.timeout":  NewValue("30s"),
+		"database.replicas": NewValueFromInterface([]any{"r1", "r2"}),
+		"cache.host":        NewValue("redis"),
+		"cache.port":        NewValue("6379"),
+		"labels.team":       NewValue("infra"),
+		"labels.Env":        NewValue("prod"),
+		"weights":           NewValue("[0.5, 1.5]"),
+		"ignored":           NewValue("should not be set"),
+		"Version":           NewValue("3"),
+	})
+
+	var app testAppConfig
+	if err := cfg.Unmarshal("", &app); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if app.Name != "app" || !app.Debug {
+		t.Errorf("embedded struct not decoded: %+v", app.testBaseConfig)
+	}
+	if app.Database.Host != "localhost" || app.Database.Port != 5432 {
+		t.Errorf("Database = %+v", app.Database)
+	}
+	if app.Database.Timeout != 30*time.Second {
+		t.Errorf("Database.Timeout = %v, want 30s", app.Database.Timeout)
+	}
+	if len(app.Database.Replicas) != 2 || app.Database.Replicas[1] != "r2" {
+		t.Errorf("Database.Replicas = %v", app.Database.Replicas)
+	}
+	if app.Cache == nil || app.Cache.Host != "redis" || app.Cache.Port != 6379 {
+		t.Errorf("Cache = %+v", app.Cache)
+	}
+	if app.Missing != nil {
+		t.Error("pointer without any keys should stay nil")
+	}
+	if app.Labels["team"] != "infra" || app.Labels["Env"] != "prod" {
+		t.Errorf("Labels = %v", app.Labels)
+	}
+	if len(app.Weights) != 2 || app.Weights[1] != 1.5 {
+		t.Errorf("Weights = %v", app.Weights)
+	}
+	if app.Ignored != "" {
+		t.Error("field tagged with - should be skipped")
+	}
+	if app.Version != 3 {
+		t.Errorf("Version = %d, want 3", app.Version)
+	}
+}
+
+func TestConfigImpl_UnmarshalKey(t *testing.T) {
+	cfg := newConfigImplFromMap(map[string]Value{
+		"database.host": NewValue("localhost"),
+		"database.port": NewValue("5432"),
+	})
+
+	t.Run("existing_key", func(t *testing.T) {
+		var db testDatabaseConfig
+		if err := cfg.UnmarshalKey("database", &db); err != nil {
+			t.Fatalf("UnmarshalKey() error = %v", err)
+		}
+		if db.Host != "localhost" || db.Port != 5432 {
+			t.Errorf("UnmarshalKey() = %+v", db)
+		}
+	})
+
+	t.Run("missing_key", func(t *testing.T) {
+		var db testDatabaseConfig
+		err := cfg.UnmarshalKey("cache", &db)
+		if !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("UnmarshalKey() error = %v, want ErrKeyNotFound", err)
+		}
+	})
+
+	t.Run("non_pointer", func(t *testing.T) {
+		var db testDatabaseConfig
+		if err := cfg.UnmarshalKey("database", db); err == nil {
+			t.Error("UnmarshalKey() should reject non-pointer target")
+		}
+	})
+}
+
+func TestConfigImpl_Unmarshal_Errors(t *testing.T) {
+	cfg := newConfigImplFromMap(map[string]Value{
+		"database.port":    NewValue("not_a_port"),
+		"database.timeout": NewValue("30 s"),
+	})
+
+	var db testDatabaseConfig
+	err := cfg.Unmarshal("database", &db)
+	if err == nil {
+		t.Fatal("Unmarshal() should fail on invalid values")
+	}
+	if !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("error should wrap ErrTypeMismatch, got %v", err)
+	}
+
+	var keyErr *KeyError
+	if !errors.As(err, &keyErr) {
+		t.Fatalf("error should contain KeyError, got %T", err)
+	}
+
+	msg := err.Error()
+	for _, want := range []string{"database.port", "database.timeout", "30 s"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("error %q should mention %q", msg, want)
+		}
+	}
+}
+
+func TestConfigImpl_Unmarshal_NestedRawMap(t *testing.T) {
+	cfg := newConfigImplFromMap(map[string]Value{
+		"database": NewValueFromInterface(map[string]any{
+			"host": "db.local",
+			"port": 3306,
+		}),
+	})
+
+	var db testDatabaseConfig
+	if err := cfg.UnmarshalKey("database", &db); err != nil {
+		t.Fatalf("UnmarshalKey() error = %v", err)
+	}
+	if db.Host != "db.local" || db.Port != 3306 {
+		t.Errorf("UnmarshalKey() = %+v", db)
+	}
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"path"
+	"reflect"
+	"sort"
+)
+
+// Change 单个配置键的变化
+// 新增的 key Old 为零值，删除的 key New 为零值
+type Change struct {
+	Key string
+	Old Value
+	New Value
+}
+
+// ChangeSet 两次配置之间的变化，各列表按 key 排序
+type ChangeSet struct {
+	Added    []Change
+	Removed  []Change
+	Modified []Change
+}
+
+// Empty 判断是否没有任何变化
+func (cs ChangeSet) Empty() bool {
+	return len(cs.Added) == 0 && len(cs.Removed) == 0 && len(cs.Modified) == 0
+}
+
+// Keys 返回所有发生变化的 key（已排序）
+func (cs ChangeSet) Keys() []string {
+	keys := make([]string, 0, len(cs.Added)+len(cs.Removed)+len(cs.Modified))
+	for _, list := range [][]Change{cs.Added, cs.Removed, cs.Modified} {
+		for _, c := range list {
+			keys = append(keys, c.Key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Match 返回 key 匹配 pattern（path.Match 语法）的变化子集
+func (cs ChangeSet) Match(pattern string) ChangeSet {
+	filter := func(list []Change) []Change {
+		var result []Change
+		for _, c := range list {
+			if ok, _ := path.Match(pattern, c.Key); ok {
+				result = append(result, c)
+			}
+		}
+		return result
+	}
+	return ChangeSet{
+		Added:    filter(cs.Added),
+		Removed:  filter(cs.Removed),
+		Modified: filter(cs.Modified),
+	}
+}
+
+// withChanges 将变化集合附加到事件上，并按实际变化修正事件类型和 Keys
+// 只有新增时为 Create，只有删除时为 Delete，其余变化为 Update；没有变化时保留原类型
+func withChanges(event Event, changes ChangeSet) Event {
+	event.Changes = changes
+	event.Keys = changes.Keys()
+
+	switch {
+	case changes.Empty():
+	case len(changes.Removed) == 0 && len(changes.Modified) == 0:
+		event.Type = EventTypeCreate
+	case len(changes.Added) == 0 && len(changes.Modified) == 0:
+		event.Type = EventTypeDelete
+	default:
+		event.Type = EventTypeUpdate
+	}
+	return event
+}
+
+// diff 计算新旧配置映射之间的变化
+func diff(oldData, newData map[string]Value) ChangeSet {
+	var cs ChangeSet
+	for key, newVal := range newData {
+		oldVal, ok := oldData[key]
+		switch {
+		case !ok:
+			cs.Added = append(cs.Added, Change{Key: key, New: newVal})
+		case !reflect.DeepEqual(oldVal.Raw(), newVal.Raw()):
+			cs.Modified = append(cs.Modified, Change{Key: key, Old: oldVal, New: newVal})
+		}
+	}
+	for key, oldVal := range oldData {
+		if _, ok := newData[key]; !ok {
+			cs.Removed = append(cs.Removed, Change{Key: key, Old: oldVal})
+		}
+	}
+
+	for _, list := range [][]Change{cs.Added, cs.Removed, cs.Modified} {
+		sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+	}
+	return cs
+}
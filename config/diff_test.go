@@ -0,0 +1,101 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	oldData := map[string]Value{
+		"database.host": NewValue("a"),
+		"database.port": NewValueFromInterface(5432),
+		"server.port":   NewValue("8080"),
+		"cache.ttl":     NewValue("1m"),
+		"list":          NewValueFromInterface([]any{"x", "y"}),
+	}
+	newData := map[string]Value{
+		"database.host": NewValue("b"),
+		"database.port": NewValueFromInterface(5432),
+		"server.port":   NewValue("8080"),
+		"database.user": NewValue("app"),
+		"list":          NewValueFromInterface([]any{"x", "y"}),
+	}
+
+	cs := diff(oldData, newData)
+
+	if len(cs.Added) != 1 || cs.Added[0].Key != "database.user" || cs.Added[0].New.String() != "app" {
+		t.Errorf("Added = %+v", cs.Added)
+	}
+	if len(cs.Removed) != 1 || cs.Removed[0].Key != "cache.ttl" || cs.Removed[0].Old.String() != "1m" {
+		t.Errorf("Removed = %+v", cs.Removed)
+	}
+	if len(cs.Modified) != 1 || cs.Modified[0].Key != "database.host" ||
+		cs.Modified[0].Old.String() != "a" || cs.Modified[0].New.String() != "b" {
+		t.Errorf("Modified = %+v", cs.Modified)
+	}
+	if want := []string{"cache.ttl", "database.host", "database.user"}; !slices.Equal(cs.Keys(), want) {
+		t.Errorf("Keys() = %v, want %v", cs.Keys(), want)
+	}
+
+	t.Run("no_changes", func(t *testing.T) {
+		if !diff(oldData, oldData).Empty() {
+			t.Error("diff() of identical maps should be empty")
+		}
+	})
+}
+
+func TestChangeSet_Match(t *testing.T) {
+	cs := ChangeSet{
+		Added:    []Change{{Key: "database.user"}, {Key: "server.port"}},
+		Removed:  []Change{{Key: "database.pool.size"}},
+		Modified: []Change{{Key: "database"}},
+	}
+
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{"database.*", []string{"database.pool.size", "database.user"}},
+		{"server.port", []string{"server.port"}},
+		{"*", []string{"database", "database.pool.size", "database.user", "server.port"}},
+		{"cache.*", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			if got := cs.Match(tt.pattern).Keys(); !slices.Equal(got, tt.want) {
+				t.Errorf("Match(%q).Keys() = %v, want %v", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithChanges(t *testing.T) {
+	added := []Change{{Key: "a"}}
+	removed := []Change{{Key: "r"}}
+	modified := []Change{{Key: "m"}}
+
+	tests := []struct {
+		name    string
+		changes ChangeSet
+		want    EventType
+	}{
+		{"no_changes", ChangeSet{}, EventTypeReload},
+		{"only_added", ChangeSet{Added: added}, EventTypeCreate},
+		{"only_removed", ChangeSet{Removed: removed}, EventTypeDelete},
+		{"only_modified", ChangeSet{Modified: modified}, EventTypeUpdate},
+		{"mixed", ChangeSet{Added: added, Removed: removed}, EventTypeUpdate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := withChanges(Event{Type: EventTypeReload, Keys: []string{"stale"}}, tt.changes)
+			if event.Type != tt.want {
+				t.Errorf("Type = %v, want %v", event.Type, tt.want)
+			}
+			if !slices.Equal(event.Keys, tt.changes.Keys()) {
+				t.Errorf("Keys = %v, want %v", event.Keys, tt.changes.Keys())
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// EncryptedPrefix 加密值前缀
+// 完整格式为 enc:v1:AES256GCM:<key-id>:<base64(nonce|ciphertext)>
+const EncryptedPrefix = "enc:v1:"
+
+// encryptedAlgorithm 当前唯一支持的加密算法
+const encryptedAlgorithm = "AES256GCM"
+
+// KeySize AES-256 密钥长度（字节）
+const KeySize = 32
+
+// Keyring 本地密钥环
+// 包含多个按 ID 区分的密钥，加密使用主密钥，解密按密文中的 key ID 查找
+// 轮换密钥时将新密钥设为主密钥并保留旧密钥，旧密文仍可解密
+type Keyring struct {
+	mu      sync.RWMutex
+	keys    map[string]cipher.AEAD
+	primary string
+}
+
+// NewKeyring 创建空密钥环
+func NewKeyring() *Keyring {
+	return &Keyring{keys: make(map[string]cipher.AEAD)}
+}
+
+// GenerateKey 生成随机的 AES-256 密钥
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Add 添加密钥，第一个添加的密钥成为主密钥
+func (k *Keyring) Add(id string, key []byte) error {
+	if id == "" || strings.ContainsAny(id, ": \t\n") {
+		return fmt.Errorf("invalid key id %q", id)
+	}
+	if len(key) != KeySize {
+		return fmt.Errorf("key %q: invalid key size %d, want %d", id, len(key), KeySize)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return fmt.Errorf("key %q: %w", id, err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return fmt.Errorf("key %q: %w", id, err)
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys[id] = aead
+	if k.primary == "" {
+		k.primary = id
+	}
+	return nil
+}
+
+// SetPrimary 设置用于加密的主密钥
+func (k *Keyring) SetPrimary(id string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if _, ok := k.keys[id]; !ok {
+		return fmt.Errorf("unknown key id %q", id)
+	}
+	k.primary = id
+	return nil
+}
+
+// Primary 返回主密钥 ID
+func (k *Keyring) Primary() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.primary
+}
+
+// Encrypt 使用主密钥加密明文，返回 enc:v1:... 形式的字符串
+func (k *Keyring) Encrypt(plaintext string) (string, error) {
+	k.mu.RLock()
+	id, aead := k.primary, k.keys[k.primary]
+	k.mu.RUnlock()
+	if aead == nil {
+		return "", errors.New("keyring has no primary key")
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	header := encryptedHeader(id)
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(header))
+	return header + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt 解密 enc:v1:... 形式的字符串
+// 错误中不包含明文内容
+func (k *Keyring) Decrypt(s string) (string, error) {
+	rest, ok := strings.CutPrefix(s, EncryptedPrefix)
+	if !ok {
+		return "", fmt.Errorf("%w: missing %q prefix", ErrDecryptFailed, EncryptedPrefix)
+	}
+	parts := strings.SplitN(rest, ":", 3)
+	if len(parts) != 3 {
+		return "", fmt.Errorf("%w: malformed encrypted value", ErrDecryptFailed)
+	}
+	algorithm, id, payload := parts[0], parts[1], parts[2]
+	if algorithm != encryptedAlgorithm {
+		return "", fmt.Errorf("%w: unsupported algorithm %q", ErrDecryptFailed, algorithm)
+	}
+
+	k.mu.RLock()
+	aead := k.keys[id]
+	k.mu.RUnlock()
+	if aead == nil {
+		return "", fmt.Errorf("%w: unknown key id %q", ErrDecryptFailed, id)
+	}
+
+	sealed, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("%w: malformed payload", ErrDecryptFailed)
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(encryptedHeader(id)))
+	if err != nil {
+		return "", fmt.Errorf("%w: key %q: authentication failed", ErrDecryptFailed, id)
+	}
+	return string(plaintext), nil
+}
+
+// encryptedHeader 返回密文头部，同时作为 GCM 附加认证数据，防止篡改 key ID
+func encryptedHeader(id string) string {
+	return EncryptedPrefix + encryptedAlgorithm + ":" + id + ":"
+}
+
+// ParseKeyring 解析密钥环文本
+// 每项格式为 <key-id>=<base64 密钥>，以换行或逗号分隔，# 开头的行为注释
+// 第一项为主密钥
+func ParseKeyring(text string) (*Keyring, error) {
+	k := NewKeyring()
+	fields := strings.FieldsFunc(text, func(r rune) bool { return r == '\n' || r == ',' })
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if field == "" || strings.HasPrefix(field, "#") {
+			continue
+		}
+		id, encoded, ok := strings.Cut(field, "=")
+		if !ok {
+			return nil, errors.New("invalid keyring entry, want <key-id>=<base64 key>")
+		}
+		id = strings.TrimSpace(id)
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("key %q: invalid base64 encoding", id)
+		}
+		if err := k.Add(id, key); err != nil {
+			return nil, err
+		}
+	}
+	if k.Primary() == "" {
+		return nil, errors.New("keyring is empty")
+	}
+	return k, nil
+}
+
+// LoadKeyringFile 从文件加载密钥环，格式见 ParseKeyring
+func LoadKeyringFile(path string) (*Keyring, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keyring %s: %w", path, err)
+	}
+	k, err := ParseKeyring(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("keyring %s: %w", path, err)
+	}
+	return k, nil
+}
+
+// LoadKeyringEnv 从环境变量加载密钥环，格式见 ParseKeyring
+// 例如: CONFIG_KEYS="k2=<base64>,k1=<base64>"
+func LoadKeyringEnv(name string) (*Keyring, error) {
+	text, ok := os.LookupEnv(name)
+	if !ok {
+		return nil, fmt.Errorf("keyring env %s is not set", name)
+	}
+	k, err := ParseKeyring(text)
+	if err != nil {
+		return nil, fmt.Errorf("keyring env %s: %w", name, err)
+	}
+	return k, nil
+}
+
+// decryptValues 解密配置中的所有加密值，返回新的配置映射
+// 解密出的值标记为敏感；错误中只包含 key 和失败原因，不包含明文
+func decryptValues(data map[string]Value, keyring *Keyring) (map[string]Value, error) {
+	var errs []error
+	result := make(map[string]Value, len(data))
+
+	for key, val := range data {
+		s, ok := val.Raw().(string)
+		if !ok || !strings.HasPrefix(s, EncryptedPrefix) {
+			result[key] = val
+			continue
+		}
+
+		plaintext, err := keyring.Decrypt(s)
+		if err != nil {
+			errs = append(errs, &KeyError{Key: key, Value: s, Err: err})
+			continue
+		}
+		result[key] = NewValue(plaintext).AsSensitive()
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return result, nil
+}
@@ -0,0 +1,256 @@
+package config
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestKeyring(t *testing.T, ids ...string) *Keyring {
+	t.Helper()
+	k := NewKeyring()
+	for _, id := range ids {
+		key, err := GenerateKey()
+		if err != nil {
+			t.Fatalf("GenerateKey() error = %v", err)
+		}
+		if err := k.Add(id, key); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	return k
+}
+
+func TestKeyring_EncryptDecrypt(t *testing.T) {
+	k := newTestKeyring(t, "k1")
+
+	enc, err := k.Encrypt("p@ss")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if !strings.HasPrefix(enc, "enc:v1:AES256GCM:k1:") {
+		t.Errorf("Encrypt() = %q, want enc:v1:AES256GCM:k1: prefix", enc)
+	}
+	if strings.Contains(enc, "p@ss") {
+		t.Error("ciphertext should not contain plaintext")
+	}
+
+	got, err := k.Decrypt(enc)
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if got != "p@ss" {
+		t.Errorf("Decrypt() = %q, want %q", got, "p@ss")
+	}
+
+	t.Run("random_nonce", func(t *testing.T) {
+		again, _ := k.Encrypt("p@ss")
+		if again == enc {
+			t.Error("Encrypt() should produce different ciphertext each time")
+		}
+	})
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	k := newTestKeyring(t, "old", "new")
+
+	oldEnc, _ := k.Encrypt("v1")
+	if err := k.SetPrimary("new"); err != nil {
+		t.Fatalf("SetPrimary() error = %v", err)
+	}
+	newEnc, _ := k.Encrypt("v2")
+
+	if !strings.Contains(newEnc, ":new:") {
+		t.Errorf("Encrypt() after rotation = %q, want key id new", newEnc)
+	}
+	for enc, want := range map[string]string{oldEnc: "v1", newEnc: "v2"} {
+		if got, err := k.Decrypt(enc); err != nil || got != want {
+			t.Errorf("Decrypt() = %q, %v, want %q", got, err, want)
+		}
+	}
+
+	if err := k.SetPrimary("missing"); err == nil {
+		t.Error("SetPrimary() with unknown id should fail")
+	}
+}
+
+func TestKeyring_DecryptErrors(t *testing.T) {
+	k := newTestKeyring(t, "k1")
+	other := newTestKeyring(t, "k1")
+	valid, _ := k.Encrypt("p@ss")
+	foreign, _ := other.Encrypt("p@ss")
+
+	// 翻转密文最后一个字节
+	header, payload := valid[:strings.LastIndex(valid, ":")+1], valid[strings.LastIndex(valid, ":")+1:]
+	sealed, _ := base64.RawURLEncoding.DecodeString(payload)
+	sealed[len(sealed)-1] ^= 0xff
+	tampered := header + base64.RawURLEncoding.EncodeToString(sealed)
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"no_prefix", "plain"},
+		{"malformed", "enc:v1:AES256GCM"},
+		{"unknown_algorithm", "enc:v1:DES:k1:AAAA"},
+		{"unknown_key", strings.Replace(valid, ":k1:", ":k9:", 1)},
+		{"bad_base64", "enc:v1:AES256GCM:k1:!!!"},
+		{"short_payload", "enc:v1:AES256GCM:k1:AAAA"},
+		{"wrong_key", foreign},
+		{"tampered", tampered},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := k.Decrypt(tt.input)
+			if !errors.Is(err, ErrDecryptFailed) {
+				t.Errorf("Decrypt() error = %v, want ErrDecryptFailed", err)
+			}
+		})
+	}
+}
+
+func TestParseKeyring(t *testing.T) {
+	key1 := base64.StdEncoding.EncodeToString(make([]byte, KeySize))
+	key2 := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", KeySize)))
+
+	tests := []struct {
+		name        string
+		text        string
+		wantPrimary string
+		wantErr     bool
+	}{
+		{"lines", "# keys\nk2=" + key2 + "\nk1=" + key1 + "\n", "k2", false},
+		{"comma_separated", "k1=" + key1 + ", k2=" + key2, "k1", false},
+		{"empty", "# nothing\n", "", true},
+		{"missing_separator", "k1" + key1, "", true},
+		{"bad_base64", "k1=***", "", true},
+		{"wrong_size", "k1=" + base64.StdEncoding.EncodeToString([]byte("short")), "", true},
+		{"bad_id", "a:b=" + key1, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := ParseKeyring(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseKeyring() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && k.Primary() != tt.wantPrimary {
+				t.Errorf("Primary() = %q, want %q", k.Primary(), tt.wantPrimary)
+			}
+		})
+	}
+}
+
+func TestLoadKeyring(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(make([]byte, KeySize))
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "keyring")
+		if err := os.WriteFile(path, []byte("main="+key+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		k, err := LoadKeyringFile(path)
+		if err != nil {
+			t.Fatalf("LoadKeyringFile() error = %v", err)
+		}
+		if k.Primary() != "main" {
+			t.Errorf("Primary() = %q, want main", k.Primary())
+		}
+	})
+
+	t.Run("file_missing", func(t *testing.T) {
+		if _, err := LoadKeyringFile(filepath.Join(t.TempDir(), "missing")); err == nil {
+			t.Error("LoadKeyringFile() should fail for missing file")
+		}
+	})
+
+	t.Run("env", func(t *testing.T) {
+		t.Setenv("KEYRING_TEST_KEYS", "env1="+key)
+		k, err := LoadKeyringEnv("KEYRING_TEST_KEYS")
+		if err != nil {
+			t.Fatalf("LoadKeyringEnv() error = %v", err)
+		}
+		if k.Primary() != "env1" {
+			t.Errorf("Primary() = %q, want env1", k.Primary())
+		}
+	})
+
+	t.Run("env_unset", func(t *testing.T) {
+		if _, err := LoadKeyringEnv("KEYRING_TEST_UNSET"); err == nil {
+			t.Error("LoadKeyringEnv() should fail when env is unset")
+		}
+	})
+}
+
+func TestManager_WithKeyring(t *testing.T) {
+	k := newTestKeyring(t, "k1")
+	enc, _ := k.Encrypt("p@ss")
+
+	source := &mockSource{
+		name: "file",
+		data: map[string]Value{
+			"database.password": NewValue(enc),
+			"database.url":      NewValue("postgres://app:${database.password}@db/app"),
+			"database.host":     NewValue("localhost"),
+		},
+	}
+
+	t.Run("decrypt", func(t *testing.T) {
+		m := NewManager(WithKeyring(k), WithInterpolation())
+		m.AddSource(source)
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		cfg := m.Config()
+		if got := cfg.GetString("database.password", ""); got != "p@ss" {
+			t.Errorf("database.password = %q, want decrypted value", got)
+		}
+		if got := cfg.GetString("database.url", ""); got != "postgres://app:p@ss@db/app" {
+			t.Errorf("database.url = %q", got)
+		}
+		if val, _ := cfg.Get("database.password"); !val.Sensitive() {
+			t.Error("decrypted value should be sensitive")
+		}
+	})
+
+	t.Run("decrypted_not_interpolated", func(t *testing.T) {
+		enc, _ := k.Encrypt("pa${ss")
+		m := NewManager(WithKeyring(k), WithInterpolation())
+		m.AddSource(&mockSource{name: "file", data: map[string]Value{"database.password": NewValue(enc)}})
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if got := m.Config().GetString("database.password", ""); got != "pa${ss" {
+			t.Errorf("database.password = %q, want plaintext kept verbatim", got)
+		}
+	})
+
+	t.Run("without_keyring", func(t *testing.T) {
+		m := NewManager()
+		m.AddSource(source)
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if got := m.Config().GetString("database.password", ""); got != enc {
+			t.Errorf("database.password = %q, want raw ciphertext", got)
+		}
+	})
+
+	t.Run("unknown_key_fails_load", func(t *testing.T) {
+		m := NewManager(WithKeyring(newTestKeyring(t, "other")))
+		m.AddSource(source)
+		err := m.Load(context.Background())
+		if !errors.Is(err, ErrDecryptFailed) {
+			t.Fatalf("Load() error = %v, want ErrDecryptFailed", err)
+		}
+		if !strings.Contains(err.Error(), "database.password") {
+			t.Errorf("error %q should mention the key", err)
+		}
+	})
+}
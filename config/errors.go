@@ -20,6 +20,30 @@ var (
 
 	// ErrWatchFailed 启动监听失败
 	ErrWatchFailed = errors.New("failed to start config watch")
+
+	// ErrUnresolvedReference 变量引用无法解析
+	ErrUnresolvedReference = errors.New("unresolved config reference")
+
+	// ErrReferenceCycle 变量引用存在循环
+	ErrReferenceCycle = errors.New("config reference cycle")
+
+	// ErrSecretNotResolved 密钥引用解析失败
+	ErrSecretNotResolved = errors.New("failed to resolve secret")
+
+	// ErrDecryptFailed 加密值解密失败
+	ErrDecryptFailed = errors.New("failed to decrypt config value")
+
+	// ErrValidationFailed 配置校验失败
+	ErrValidationFailed = errors.New("config validation failed")
+
+	// ErrUnknownKey 严格模式下存在未声明的配置键
+	ErrUnknownKey = errors.New("unknown config key")
+
+	// ErrRequiredKeyMissing 必需配置键缺失
+	ErrRequiredKeyMissing = errors.New("required config key missing")
+
+	// ErrSnapshotCorrupt 快照文件损坏或校验和不匹配
+	ErrSnapshotCorrupt = errors.New("config snapshot corrupt")
 )
 
 // SourceError 配置源错误
@@ -35,3 +59,19 @@ func (e *SourceError) Error() string {
 func (e *SourceError) Unwrap() error {
 	return e.Err
 }
+
+// KeyError 配置键错误
+// 记录出错的配置键及其原始值，便于定位问题
+type KeyError struct {
+	Key   string
+	Value any // 原始值，键不存在时为 nil
+	Err   error
+}
+
+func (e *KeyError) Error() string {
+	return fmt.Sprintf("key %s: %v", e.Key, e.Err)
+}
+
+func (e *KeyError) Unwrap() error {
+	return e.Err
+}
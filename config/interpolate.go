@@ -0,0 +1,211 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// interpolator 在合并后的配置上展开变量引用
+//
+// 支持的语法:
+//
+//	${database.host}          引用其他配置键
+//	${env:HOME}               引用环境变量
+//	${database.port:-5432}    引用不存在或为空时使用默认值（默认值中可继续引用）
+//	$${literal}               转义，输出 ${literal}
+//
+// 值恰好为单个引用时保留被引用值的原始类型；引用了敏感值的结果同样标记为敏感
+type interpolator struct {
+	data      map[string]Value
+	literal   map[string]bool // 原样保留、不展开的 key
+	resolved  map[string]Value
+	failed    map[string]error
+	stack     []string // 正在解析的 key，用于循环引用检测
+	lookupEnv func(string) (string, bool)
+}
+
+// interpolate 展开配置中的所有变量引用，返回新的配置映射
+// literal 中的 key 原样保留，其他值仍可引用它们
+func interpolate(data map[string]Value, literal map[string]bool) (map[string]Value, error) {
+	ip := &interpolator{
+		data:      data,
+		literal:   literal,
+		resolved:  make(map[string]Value, len(data)),
+		failed:    make(map[string]error),
+		lookupEnv: os.LookupEnv,
+	}
+
+	var errs []error
+	result := make(map[string]Value, len(data))
+	for key := range data {
+		val, err := ip.resolve(key)
+		if err != nil {
+			// 由被引用 key 传递上来的错误只在该 key 处报告一次
+			var keyErr *KeyError
+			if !errors.As(err, &keyErr) || keyErr.Key == key {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		result[key] = val
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return result, nil
+}
+
+// resolve 解析单个配置键的最终值
+func (ip *interpolator) resolve(key string) (Value, error) {
+	if val, ok := ip.resolved[key]; ok {
+		return val, nil
+	}
+	if err, ok := ip.failed[key]; ok {
+		return Value{}, err
+	}
+	for i, k := range ip.stack {
+		if k == key {
+			chain := strings.Join(append(slices.Clone(ip.stack[i:]), key), " -> ")
+			return Value{}, &KeyError{Key: key, Err: fmt.Errorf("%w: %s", ErrReferenceCycle, chain)}
+		}
+	}
+
+	val := ip.data[key]
+	s, ok := val.Raw().(string)
+	if !ok || ip.literal[key] || !strings.Contains(s, "${") {
+		ip.resolved[key] = val
+		return val, nil
+	}
+
+	ip.stack = append(ip.stack, key)
+	expanded, err := ip.expand(s)
+	ip.stack = ip.stack[:len(ip.stack)-1]
+	if err != nil {
+		// 被引用 key 的错误已包含其自身的 key 信息
+		var keyErr *KeyError
+		if !errors.As(err, &keyErr) {
+			var raw any = s
+			if val.Sensitive() {
+				raw = Redacted
+			}
+			err = &KeyError{Key: key, Value: raw, Err: err}
+		}
+		ip.failed[key] = err
+		return Value{}, err
+	}
+
+	ip.resolved[key] = expanded
+	return expanded, nil
+}
+
+// expand 展开字符串中的所有引用
+func (ip *interpolator) expand(s string) (Value, error) {
+	var b strings.Builder
+	rest := s
+	sensitive := false
+
+	for {
+		i := strings.Index(rest, "${")
+		if i < 0 {
+			b.WriteString(rest)
+			break
+		}
+
+		// $${ 转义为字面量 ${
+		if i > 0 && rest[i-1] == '$' {
+			b.WriteString(rest[:i-1])
+			b.WriteString("${")
+			rest = rest[i+2:]
+			continue
+		}
+
+		end := matchingBrace(rest, i+2)
+		if end < 0 {
+			// 错误中不包含原始值，避免泄露敏感内容
+			return Value{}, fmt.Errorf("%w: unclosed reference at offset %d", ErrUnresolvedReference, len(s)-len(rest)+i)
+		}
+
+		val, err := ip.reference(rest[i+2 : end])
+		if err != nil {
+			return Value{}, err
+		}
+
+		// 整个值就是一个引用时保留原始类型
+		if rest == s && i == 0 && end == len(s)-1 {
+			return val, nil
+		}
+
+		b.WriteString(rest[:i])
+		b.WriteString(val.String())
+		rest = rest[end+1:]
+		sensitive = sensitive || val.Sensitive()
+	}
+
+	if sensitive {
+		return NewValue(b.String()).AsSensitive(), nil
+	}
+	return NewValue(b.String()), nil
+}
+
+// reference 解析单个引用表达式
+func (ip *interpolator) reference(expr string) (Value, error) {
+	name, fallback, hasFallback := strings.Cut(expr, ":-")
+
+	if envName, ok := strings.CutPrefix(name, "env:"); ok {
+		if v, ok := ip.lookupEnv(envName); ok && (v != "" || !hasFallback) {
+			return NewValue(v), nil
+		}
+	} else if _, ok := ip.data[name]; ok {
+		val, err := ip.resolve(name)
+		if err != nil {
+			return Value{}, err
+		}
+		if val.String() != "" || !hasFallback {
+			return val, nil
+		}
+	}
+
+	if hasFallback {
+		return ip.expand(fallback)
+	}
+	return Value{}, fmt.Errorf("%w: ${%s}", ErrUnresolvedReference, name)
+}
+
+// literalKeys 返回不参与插值的 key：加密值和密钥引用
+// 它们解析出的是密钥明文，其中的 ${ 不是引用，也不能出现在插值错误中
+func literalKeys(data map[string]Value, decrypt, resolve bool) map[string]bool {
+	literal := make(map[string]bool)
+	for key, val := range data {
+		s, ok := val.Raw().(string)
+		if !ok {
+			continue
+		}
+		_, _, isRef := parseSecretRef(s)
+		if (decrypt && strings.HasPrefix(s, EncryptedPrefix)) || (resolve && isRef) {
+			literal[key] = true
+		}
+	}
+	return literal
+}
+
+// matchingBrace 返回与 start 之前的 "${" 匹配的 "}" 位置，支持嵌套引用
+func matchingBrace(s string, start int) int {
+	depth := 1
+	for i := start; i < len(s); i++ {
+		switch {
+		case s[i] == '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		case s[i] == '$' && i+1 < len(s) && s[i+1] == '{':
+			depth++
+			i++
+		}
+	}
+	return -1
+}
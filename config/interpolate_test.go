@@ -0,0 +1,161 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestInterpolate(t *testing.T) {
+	t.Setenv("INTERPOLATE_TEST_HOME", "/home/app")
+
+	data := map[string]Value{
+		"database.host": NewValue("db.local"),
+		"database.port": NewValueFromInterface(5432),
+		"database.url":  NewValue("postgres://${database.host}:${database.port}/app"),
+		"database.dsn":  NewValue("${database.url}?sslmode=disable"),
+		"port_ref":      NewValue("${database.port}"),
+		"home":          NewValue("${env:INTERPOLATE_TEST_HOME}/data"),
+		"fallback":      NewValue("${missing.key:-localhost}"),
+		"env_fallback":  NewValue("${env:INTERPOLATE_TEST_MISSING:-${database.host}}"),
+		"escaped":       NewValue("$${database.host}"),
+		"plain":         NewValue("no references"),
+		"number":        NewValueFromInterface(42),
+	}
+
+	result, err := interpolate(data, nil)
+	if err != nil {
+		t.Fatalf("interpolate() error = %v", err)
+	}
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"database.url", "postgres://db.local:5432/app"},
+		{"database.dsn", "postgres://db.local:5432/app?sslmode=disable"},
+		{"home", "/home/app/data"},
+		{"fallback", "localhost"},
+		{"env_fallback", "db.local"},
+		{"escaped", "${database.host}"},
+		{"plain", "no references"},
+		{"number", "42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := result[tt.key].String(); got != tt.want {
+				t.Errorf("%s = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("preserve_type", func(t *testing.T) {
+		if raw, ok := result["port_ref"].Raw().(int); !ok || raw != 5432 {
+			t.Errorf("single reference should keep raw type, got %T %v", result["port_ref"].Raw(), result["port_ref"].Raw())
+		}
+	})
+
+	t.Run("input_unchanged", func(t *testing.T) {
+		if data["database.url"].String() != "postgres://${database.host}:${database.port}/app" {
+			t.Error("interpolate() should not modify the input map")
+		}
+	})
+}
+
+func TestInterpolate_Errors(t *testing.T) {
+	t.Run("unresolved", func(t *testing.T) {
+		_, err := interpolate(map[string]Value{
+			"url": NewValue("http://${missing.host}/"),
+		}, nil)
+		if !errors.Is(err, ErrUnresolvedReference) {
+			t.Fatalf("interpolate() error = %v, want ErrUnresolvedReference", err)
+		}
+		if !strings.Contains(err.Error(), "url") || !strings.Contains(err.Error(), "missing.host") {
+			t.Errorf("error %q should mention key and reference", err)
+		}
+	})
+
+	t.Run("unclosed", func(t *testing.T) {
+		_, err := interpolate(map[string]Value{"bad": NewValue("${oops")}, nil)
+		if !errors.Is(err, ErrUnresolvedReference) {
+			t.Errorf("interpolate() error = %v, want ErrUnresolvedReference", err)
+		}
+		if strings.Contains(err.Error(), "oops") {
+			t.Errorf("error %q should not contain the raw value", err)
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		_, err := interpolate(map[string]Value{
+			"a":    NewValue("${b}"),
+			"b":    NewValue("x-${c}"),
+			"c":    NewValue("${a}"),
+			"safe": NewValue("ok"),
+		}, nil)
+		if !errors.Is(err, ErrReferenceCycle) {
+			t.Fatalf("interpolate() error = %v, want ErrReferenceCycle", err)
+		}
+		if n := strings.Count(err.Error(), "reference cycle"); n != 1 {
+			t.Errorf("cycle should be reported once, got %d in %q", n, err)
+		}
+	})
+
+	t.Run("self_reference", func(t *testing.T) {
+		_, err := interpolate(map[string]Value{"a": NewValue("${a}")}, nil)
+		if !errors.Is(err, ErrReferenceCycle) {
+			t.Errorf("interpolate() error = %v, want ErrReferenceCycle", err)
+		}
+	})
+}
+
+func TestManager_WithInterpolation(t *testing.T) {
+	low := &mockSource{
+		name:     "file",
+		priority: 60,
+		data: map[string]Value{
+			"database.host": NewValue("file.host"),
+			"database.url":  NewValue("postgres://${database.host}/app"),
+		},
+	}
+	high := &mockSource{
+		name:     "env",
+		priority: 100,
+		data:     map[string]Value{"database.host": NewValue("env.host")},
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		m := NewManager(WithInterpolation())
+		m.AddSource(low, high)
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		// 引用在合并后展开，使用高优先级配置源的值
+		if got := m.Config().GetString("database.url", ""); got != "postgres://env.host/app" {
+			t.Errorf("database.url = %q", got)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		m := NewManager()
+		m.AddSource(low, high)
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if got := m.Config().GetString("database.url", ""); got != "postgres://${database.host}/app" {
+			t.Errorf("database.url = %q, want raw value", got)
+		}
+	})
+
+	t.Run("unresolved_fails_load", func(t *testing.T) {
+		m := NewManager(WithInterpolation())
+		m.AddSource(&mockSource{
+			name: "file",
+			data: map[string]Value{"url": NewValue("${nope}")},
+		})
+		if err := m.Load(context.Background()); !errors.Is(err, ErrUnresolvedReference) {
+			t.Errorf("Load() error = %v, want ErrUnresolvedReference", err)
+		}
+	})
+}
@@ -0,0 +1,218 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Get 泛型获取配置值
+// 常用类型通过 Value 的转换方法处理，其余类型（结构体、切片、map 等）通过 UnmarshalKey 解码
+// key 不存在时返回 ErrKeyNotFound，转换失败时返回 ErrTypeMismatch
+//
+//	timeout, err := config.Get[time.Duration](cfg, "server.timeout")
+func Get[T any](cfg Config, key string) (T, error) {
+	var result T
+
+	switch ptr := any(&result).(type) {
+	case *string:
+		v, err := cfg.GetStringE(key)
+		*ptr = v
+		return result, err
+	case *int:
+		v, err := cfg.GetIntE(key)
+		*ptr = v
+		return result, err
+	case *int64:
+		v, err := cfg.GetInt64E(key)
+		*ptr = v
+		return result, err
+	case *float64:
+		v, err := cfg.GetFloat64E(key)
+		*ptr = v
+		return result, err
+	case *bool:
+		v, err := cfg.GetBoolE(key)
+		*ptr = v
+		return result, err
+	case *time.Duration:
+		v, err := cfg.GetDurationE(key)
+		*ptr = v
+		return result, err
+	case *[]string:
+		v, err := cfg.GetStringSliceE(key)
+		*ptr = v
+		return result, err
+	case *map[string]string:
+		v, err := cfg.GetStringMapE(key)
+		*ptr = v
+		return result, err
+	case *Value:
+		v, ok := cfg.Get(key)
+		if !ok {
+			return result, &KeyError{Key: key, Err: ErrKeyNotFound}
+		}
+		*ptr = v
+		return result, nil
+	default:
+		if err := cfg.UnmarshalKey(key, &result); err != nil {
+			var zero T
+			return zero, err
+		}
+		return result, nil
+	}
+}
+
+// Key 类型化的配置键声明
+// 声明时携带默认值、描述和可选的校验函数，并注册到 DefaultRegistry
+//
+//	var ServerTimeout = config.NewKey("server.timeout", 30*time.Second, "HTTP 服务超时时间")
+//	timeout, err := ServerTimeout.Get(cfg)
+type Key[T any] struct {
+	name        string
+	defaultVal  T
+	description string
+	validator   func(T) error
+	sensitive   bool
+}
+
+// KeyOption 配置键声明选项
+type KeyOption[T any] func(*Key[T])
+
+// WithValidator 设置配置值校验函数
+// 校验失败时 Get 返回包装了该错误的 *KeyError
+func WithValidator[T any](fn func(T) error) KeyOption[T] {
+	return func(k *Key[T]) {
+		k.validator = fn
+	}
+}
+
+// WithSensitive 将配置键声明为敏感
+// Manager 加载配置时会将其值标记为敏感，打印时显示为 ******
+//
+//	config.NewKey("database.password", "", "数据库密码", config.WithSensitive[string]())
+func WithSensitive[T any]() KeyOption[T] {
+	return func(k *Key[T]) {
+		k.sensitive = true
+	}
+}
+
+// NewKey 声明配置键并注册到 DefaultRegistry
+func NewKey[T any](name string, defaultVal T, description string, opts ...KeyOption[T]) *Key[T] {
+	k := &Key[T]{
+		name:        name,
+		defaultVal:  defaultVal,
+		description: description,
+	}
+
+	for _, opt := range opts {
+		opt(k)
+	}
+
+	DefaultRegistry.Register(KeyInfo{
+		Name:        name,
+		Type:        reflect.TypeOf((*T)(nil)).Elem().String(),
+		Default:     defaultVal,
+		Description: description,
+		Sensitive:   k.sensitive,
+	})
+
+	return k
+}
+
+// Name 返回配置键名称
+func (k *Key[T]) Name() string {
+	return k.name
+}
+
+// Default 返回默认值
+func (k *Key[T]) Default() T {
+	return k.defaultVal
+}
+
+// Description 返回配置键描述
+func (k *Key[T]) Description() string {
+	return k.description
+}
+
+// Get 读取配置值
+// key 不存在时返回默认值；转换或校验失败时返回默认值和错误
+func (k *Key[T]) Get(cfg Config) (T, error) {
+	val, err := Get[T](cfg, k.name)
+	if err != nil {
+		if errors.Is(err, ErrKeyNotFound) {
+			return k.defaultVal, nil
+		}
+		return k.defaultVal, err
+	}
+
+	if k.validator != nil {
+		if err := k.validator(val); err != nil {
+			raw, _ := cfg.Get(k.name)
+			if k.sensitive {
+				raw = raw.AsSensitive()
+			}
+			return k.defaultVal, &KeyError{Key: k.name, Value: errorValue(raw), Err: err}
+		}
+	}
+
+	return val, nil
+}
+
+// KeyInfo 已注册配置键的描述信息
+type KeyInfo struct {
+	Name        string
+	Type        string
+	Default     any
+	Description string
+	Sensitive   bool
+}
+
+// Registry 配置键注册表
+// 用于集中登记服务使用的所有配置键，便于工具生成文档或检查配置
+type Registry struct {
+	mu   sync.RWMutex
+	keys map[string]KeyInfo
+}
+
+// DefaultRegistry 默认注册表，NewKey 声明的配置键注册于此
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry 创建配置键注册表
+func NewRegistry() *Registry {
+	return &Registry{
+		keys: make(map[string]KeyInfo),
+	}
+}
+
+// Register 注册配置键，同名配置键后注册的覆盖先注册的
+func (r *Registry) Register(info KeyInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys[info.Name] = info
+}
+
+// Lookup 查找已注册的配置键
+func (r *Registry) Lookup(name string) (KeyInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	info, ok := r.keys[name]
+	return info, ok
+}
+
+// Keys 返回所有已注册的配置键，按名称排序
+func (r *Registry) Keys() []KeyInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]KeyInfo, 0, len(r.keys))
+	for _, info := range r.keys {
+		result = append(result, info)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
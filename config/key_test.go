@@ -0,0 +1,163 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestGet(t *testing.T) {
+	cfg := newConfigImplFromMap(map[string]Value{
+		"server.timeout": NewValue("30s"),
+		"server.port":    NewValue("8080"),
+		"server.debug":   NewValue("true"),
+		"server.hosts":   NewValue("a,b"),
+		"server.workers": NewValue("4"),
+		"database.host":  NewValue("localhost"),
+		"database.port":  NewValue("5432"),
+	})
+
+	t.Run("duration", func(t *testing.T) {
+		got, err := Get[time.Duration](cfg, "server.timeout")
+		if err != nil || got != 30*time.Second {
+			t.Errorf("Get[time.Duration]() = %v, %v", got, err)
+		}
+	})
+
+	t.Run("int", func(t *testing.T) {
+		got, err := Get[int](cfg, "server.port")
+		if err != nil || got != 8080 {
+			t.Errorf("Get[int]() = %v, %v", got, err)
+		}
+	})
+
+	t.Run("bool", func(t *testing.T) {
+		got, err := Get[bool](cfg, "server.debug")
+		if err != nil || !got {
+			t.Errorf("Get[bool]() = %v, %v", got, err)
+		}
+	})
+
+	t.Run("string_slice", func(t *testing.T) {
+		got, err := Get[[]string](cfg, "server.hosts")
+		if err != nil || len(got) != 2 {
+			t.Errorf("Get[[]string]() = %v, %v", got, err)
+		}
+	})
+
+	t.Run("other_numeric_type", func(t *testing.T) {
+		got, err := Get[uint8](cfg, "server.workers")
+		if err != nil || got != 4 {
+			t.Errorf("Get[uint8]() = %v, %v", got, err)
+		}
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		type db struct {
+			Host string `mapstructure:"host"`
+			Port int    `mapstructure:"port"`
+		}
+		got, err := Get[db](cfg, "database")
+		if err != nil || got.Host != "localhost" || got.Port != 5432 {
+			t.Errorf("Get[db]() = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		if _, err := Get[int](cfg, "missing"); !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("Get[int]() error = %v, want ErrKeyNotFound", err)
+		}
+		if _, err := Get[struct{}](cfg, "missing"); !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("Get[struct{}]() error = %v, want ErrKeyNotFound", err)
+		}
+	})
+
+	t.Run("type_mismatch", func(t *testing.T) {
+		if _, err := Get[time.Duration](cfg, "database.host"); !errors.Is(err, ErrTypeMismatch) {
+			t.Errorf("Get[time.Duration]() error = %v, want ErrTypeMismatch", err)
+		}
+	})
+}
+
+func TestKey(t *testing.T) {
+	port := NewKey("test.key.port", 8080, "服务端口",
+		WithValidator(func(v int) error {
+			if v <= 0 || v > 65535 {
+				return fmt.Errorf("port %d out of range", v)
+			}
+			return nil
+		}),
+	)
+
+	t.Run("metadata", func(t *testing.T) {
+		if port.Name() != "test.key.port" || port.Default() != 8080 || port.Description() != "服务端口" {
+			t.Errorf("unexpected key metadata: %s %v %s", port.Name(), port.Default(), port.Description())
+		}
+	})
+
+	t.Run("registered", func(t *testing.T) {
+		info, ok := DefaultRegistry.Lookup("test.key.port")
+		if !ok {
+			t.Fatal("NewKey() should register the key in DefaultRegistry")
+		}
+		if info.Type != "int" || info.Default != 8080 || info.Description != "服务端口" {
+			t.Errorf("KeyInfo = %+v", info)
+		}
+	})
+
+	t.Run("value_present", func(t *testing.T) {
+		cfg := newConfigImplFromMap(map[string]Value{"test.key.port": NewValue("9090")})
+		got, err := port.Get(cfg)
+		if err != nil || got != 9090 {
+			t.Errorf("Get() = %v, %v", got, err)
+		}
+	})
+
+	t.Run("default_when_missing", func(t *testing.T) {
+		got, err := port.Get(newConfigImpl())
+		if err != nil || got != 8080 {
+			t.Errorf("Get() = %v, %v, want default", got, err)
+		}
+	})
+
+	t.Run("validation_failure", func(t *testing.T) {
+		cfg := newConfigImplFromMap(map[string]Value{"test.key.port": NewValue("70000")})
+		got, err := port.Get(cfg)
+		var keyErr *KeyError
+		if !errors.As(err, &keyErr) || keyErr.Key != "test.key.port" {
+			t.Fatalf("Get() error = %v, want *KeyError", err)
+		}
+		if got != 8080 {
+			t.Errorf("Get() = %v, want default on validation failure", got)
+		}
+	})
+
+	t.Run("conversion_failure", func(t *testing.T) {
+		cfg := newConfigImplFromMap(map[string]Value{"test.key.port": NewValue("http")})
+		if _, err := port.Get(cfg); !errors.Is(err, ErrTypeMismatch) {
+			t.Errorf("Get() error = %v, want ErrTypeMismatch", err)
+		}
+	})
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	r.Register(KeyInfo{Name: "b.key", Type: "string"})
+	r.Register(KeyInfo{Name: "a.key", Type: "int"})
+	r.Register(KeyInfo{Name: "b.key", Type: "bool"})
+
+	keys := r.Keys()
+	if len(keys) != 2 {
+		t.Fatalf("Keys() returned %d keys, want 2", len(keys))
+	}
+	if keys[0].Name != "a.key" || keys[1].Name != "b.key" {
+		t.Errorf("Keys() should be sorted by name, got %v", keys)
+	}
+	if keys[1].Type != "bool" {
+		t.Errorf("later registration should win, got %v", keys[1].Type)
+	}
+	if _, ok := r.Lookup("missing"); ok {
+		t.Error("Lookup() should return false for unknown key")
+	}
+}
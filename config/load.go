@@ -0,0 +1,79 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// loadResult 单个配置源的加载结果
+type loadResult struct {
+	values   map[string]Value
+	err      error
+	duration time.Duration
+}
+
+// loadSources 并发加载 need 标记的配置源，结果与 sources 一一对应
+// 结果按下标保存，合并顺序仍由配置源优先级决定，与完成先后无关
+func loadSources(ctx context.Context, sources []Source, opts []sourceOptions, need []bool) []loadResult {
+	results := make([]loadResult, len(sources))
+
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		if !need[i] {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			values, err := loadSource(ctx, source, opts[i])
+			results[i] = loadResult{values: values, err: err, duration: time.Since(start)}
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// loadSource 加载单个配置源，设置了重试策略时失败后按策略重试，超时限制作用于每次尝试
+func loadSource(ctx context.Context, source Source, opts sourceOptions) (map[string]Value, error) {
+	if opts.retry == nil {
+		return loadOnce(ctx, source, opts.timeout)
+	}
+
+	var values map[string]Value
+	err := opts.retry.Do(ctx, func(ctx context.Context) error {
+		var err error
+		values, err = loadOnce(ctx, source, opts.timeout)
+		return err
+	})
+	return values, err
+}
+
+// loadOnce 在超时限制内加载一次配置源
+// 配置源不响应 ctx 取消时不再等待其返回，超时后立即返回错误
+func loadOnce(ctx context.Context, source Source, timeout time.Duration) (map[string]Value, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	done := make(chan loadResult, 1)
+	go func() {
+		values, err := source.Load(ctx)
+		done <- loadResult{values: values, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.values, r.err
+	case <-ctx.Done():
+		if timeout > 0 && ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("timed out after %s: %w", timeout, ctx.Err())
+		}
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// slowSource 延迟 delay 后返回的测试配置源
+type slowSource struct {
+	*mockSource
+	delay time.Duration
+}
+
+func (s *slowSource) Load(ctx context.Context) (map[string]Value, error) {
+	select {
+	case <-time.After(s.delay):
+		return s.mockSource.Load(ctx)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestLoadSource_Timeout(t *testing.T) {
+	hung := &blockingSource{
+		mockSource: &mockSource{name: "hung"},
+		started:    make(chan struct{}, 1),
+		release:    make(chan struct{}),
+	}
+	defer close(hung.release)
+
+	tests := []struct {
+		name    string
+		source  Source
+		timeout time.Duration
+		wantErr bool
+	}{
+		{"no_timeout", &slowSource{mockSource: &mockSource{name: "slow"}, delay: 10 * time.Millisecond}, 0, false},
+		{"within_timeout", &slowSource{mockSource: &mockSource{name: "slow"}, delay: 10 * time.Millisecond}, time.Second, false},
+		{"exceeded", &slowSource{mockSource: &mockSource{name: "slow"}, delay: time.Second}, 20 * time.Millisecond, true},
+		{"ignores_context", hung, 20 * time.Millisecond, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			_, err := loadSource(context.Background(), tt.source, sourceOptions{timeout: tt.timeout})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadSource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "timed out after") {
+					t.Errorf("error = %v, want timeout", err)
+				}
+				if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+					t.Errorf("loadSource() took %v, want about %v", elapsed, tt.timeout)
+				}
+			}
+		})
+	}
+}
+
+func TestManager_ParallelLoad(t *testing.T) {
+	t.Run("concurrent", func(t *testing.T) {
+		m := NewManager()
+		for _, name := range []string{"file", "consul", "postgres"} {
+			m.AddSource(&slowSource{mockSource: &mockSource{name: name}, delay: 100 * time.Millisecond})
+		}
+
+		start := time.Now()
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+			t.Errorf("Load() took %v, want sources loaded concurrently", elapsed)
+		}
+		for _, status := range m.SourceStatus() {
+			if status.Duration < 100*time.Millisecond {
+				t.Errorf("%s Duration = %v, want >= 100ms", status.Source, status.Duration)
+			}
+		}
+	})
+
+	t.Run("merge_order_by_priority", func(t *testing.T) {
+		// 低优先级配置源先完成，高优先级配置源后完成
+		low := &mockSource{name: "file", priority: 60, data: map[string]Value{"a": NewValue("file")}}
+		high := &slowSource{
+			mockSource: &mockSource{name: "consul", priority: 80, data: map[string]Value{"a": NewValue("consul")}},
+			delay:      50 * time.Millisecond,
+		}
+
+		m := NewManager()
+		m.AddSource(high, low)
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if got := m.Config().GetString("a", ""); got != "consul" {
+			t.Errorf("a = %q, want value from higher priority source", got)
+		}
+	})
+
+	t.Run("timeout_optional", func(t *testing.T) {
+		file := &mockSource{name: "file", data: map[string]Value{"a": NewValue("1")}}
+		consul := &slowSource{mockSource: &mockSource{name: "consul", priority: 80}, delay: time.Second}
+
+		m := NewManager()
+		m.AddSource(file).AddSourceWith(consul, WithPolicy(PolicyOptional), WithTimeout(30*time.Millisecond))
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		degraded := m.DegradedSources()
+		if len(degraded) != 1 || !errors.Is(degraded[0].Err, context.DeadlineExceeded) {
+			t.Fatalf("DegradedSources() = %+v, want timed out consul", degraded)
+		}
+		if degraded[0].Duration < 30*time.Millisecond || degraded[0].Duration > 500*time.Millisecond {
+			t.Errorf("Duration = %v, want about the timeout", degraded[0].Duration)
+		}
+	})
+
+	t.Run("timeout_required", func(t *testing.T) {
+		consul := &slowSource{mockSource: &mockSource{name: "consul"}, delay: time.Second}
+
+		m := NewManager()
+		m.AddSourceWith(consul, WithTimeout(30*time.Millisecond))
+		err := m.Load(context.Background())
+		if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "consul") {
+			t.Errorf("Load() error = %v, want timeout naming the source", err)
+		}
+	})
+}
@@ -3,22 +3,57 @@ package config
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 )
 
 // Manager 配置管理器
 // 负责协调多个配置源、执行合并、处理热更新
 type Manager struct {
 	mu       sync.RWMutex
+	loadMu   sync.Mutex // 串行化加载和绑定注册，配置源加载与候选配置构建在 mu 之外执行
 	sources  []Source
+	entries  []*sourceEntry // 与 sources 一一对应的加载选项、缓存和状态
 	merger   Merger
 	config   *configImpl
 	watchers []Watcher
 	onChange []ChangeCallback
-	ctx      context.Context
-	cancel   context.CancelFunc
-	wg       sync.WaitGroup
+
+	subscriptions []*subscription // key 模式订阅
+
+	interpolate     bool                      // 合并后展开变量引用
+	secretResolvers map[string]SecretResolver // 密钥引用解析器
+	keyring         *Keyring                  // 加密值解密密钥环
+
+	sensitivePatterns []string // 敏感 key 模式
+
+	provenance map[string]Provenance // 每个 key 的来源信息
+	bindings   []binder              // 类型化配置绑定
+	validators []ConfigValidator     // 候选配置校验函数
+	schema     *Schema               // 合并配置的 JSON Schema
+	loaded     bool                  // 是否已成功加载过配置
+
+	strictMode  StrictMode   // 未知 key 检测模式
+	knownKeys   []string     // 显式声明的已知 key
+	unknownKeys []UnknownKey // 最近一次成功加载检测到的未知 key
+
+	requiredKeys     []string // 必需配置键
+	requiredPrefixes []string // 必需配置前缀
+
+	debounceWindow  time.Duration    // 变更事件防抖窗口，0 表示每个事件立即重载
+	debounceMaxWait time.Duration    // 防抖最长等待时间
+	pending         chan sourceEvent // 待合并的变更事件
+
+	recoveryInterval time.Duration // 降级配置源的重新探测间隔，0 表示不探测
+	recovering       bool          // 重新探测 goroutine 是否已启动
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
 }
 
 // ChangeCallback 配置变更回调函数
@@ -33,8 +68,13 @@ func NewManager(opts ...ManagerOption) *Manager {
 		merger:   NewDefaultMerger(),
 		config:   newConfigImpl(),
 		onChange: make([]ChangeCallback, 0),
-		ctx:      ctx,
-		cancel:   cancel,
+
+		secretResolvers: make(map[string]SecretResolver),
+
+		sensitivePatterns: append([]string(nil), DefaultSensitivePatterns...),
+
+		ctx:    ctx,
+		cancel: cancel,
 	}
 
 	for _, opt := range opts {
@@ -50,59 +90,289 @@ func (m *Manager) AddSource(sources ...Source) *Manager {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	m.sources = append(m.sources, sources...)
-	// 按优先级排序（从低到高，便于后续合并时高优先级覆盖低优先级）
-	sort.Slice(m.sources, func(i, j int) bool {
-		return m.sources[i].Priority() < m.sources[j].Priority()
-	})
+	entries := make([]*sourceEntry, len(sources))
+	for i, source := range sources {
+		entries[i] = &sourceEntry{source: source}
+	}
+	m.addSourcesLocked(entries...)
+	return m
+}
+
+// AddSourceWith 添加配置源并设置该配置源的加载选项（如失败策略）
+func (m *Manager) AddSourceWith(source Source, opts ...SourceOption) *Manager {
+	var so sourceOptions
+	for _, opt := range opts {
+		opt(&so)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
+	m.addSourcesLocked(&sourceEntry{source: source, opts: so})
 	return m
 }
 
+// sourceEntry 已添加的配置源及其加载选项、缓存和状态
+// 按条目而不是以 Source 为 map key 保存，配置源类型无需可比较
+type sourceEntry struct {
+	source    Source
+	opts      sourceOptions
+	values    map[string]Value // 最近一次成功加载的结果
+	cached    bool             // 是否成功加载过
+	effective map[string]Value // 最近一次参与合并的结果，降级时为沿用的上次结果或快照
+	status    SourceStatus     // 最近一次加载的状态
+	attempted bool             // 是否已加载过（无论成功与否）
+}
+
+// addSourcesLocked 添加配置源并按优先级排序（需要持有锁）
+func (m *Manager) addSourcesLocked(entries ...*sourceEntry) {
+	m.entries = append(m.entries, entries...)
+	// 按优先级排序（从低到高，便于后续合并时高优先级覆盖低优先级）
+	sort.Slice(m.entries, func(i, j int) bool {
+		return m.entries[i].source.Priority() < m.entries[j].source.Priority()
+	})
+	m.sources = make([]Source, len(m.entries))
+	for i, e := range m.entries {
+		m.sources[i] = e.source
+	}
+}
+
 // Load 加载所有配置源并合并
 func (m *Manager) Load(ctx context.Context) error {
+	_, _, err := m.load(ctx, nil)
+	return err
+}
+
+// load 重新加载名称在 names 中的配置源（nil 表示全部），其余配置源使用上次参与合并的结果
+// 降级的配置源只在 Load 或定期探测时重试，Watch 事件触发的重载不会因不可达的配置源而阻塞
+// 配置源的 Load 以及解密、密钥解析、校验等候选配置的构建都在 Manager 锁之外执行，
+// 慢速或不可达的远程配置源不会阻塞 Config 等读操作，只在替换配置时短暂持有锁
+// 成功时返回替换前后的配置；失败时配置和缓存保持不变
+func (m *Manager) load(ctx context.Context, names []string) (oldConfig, newConfig *configImpl, err error) {
+	m.loadMu.Lock()
+	defer m.loadMu.Unlock()
+
+	m.mu.RLock()
+	entries := slices.Clone(m.entries)
+	sources := slices.Clone(m.sources)
+	allValues := make([]map[string]Value, len(entries))
+	last := make([]map[string]Value, len(entries))
+	cached := make([]bool, len(entries))
+	attempted := make([]bool, len(entries))
+	opts := make([]sourceOptions, len(entries))
+	statuses := make([]SourceStatus, len(entries))
+	for i, e := range entries {
+		allValues[i] = e.effective
+		last[i], cached[i], attempted[i] = e.values, e.cached, e.attempted
+		opts[i] = e.opts
+		statuses[i] = e.status
+		statuses[i].Source = e.source.Name()
+		statuses[i].Policy = opts[i].policy
+	}
+	oldConfig = m.config.clone()
+	m.mu.RUnlock()
+
+	// 新添加、尚未加载过的配置源总是需要加载
+	need := refreshSet(sources, names)
+	for i := range sources {
+		need[i] = need[i] || !attempted[i]
+	}
+	results := loadSources(ctx, sources, opts, need)
+
+	loaded := make([]bool, len(sources))
+	for i, source := range sources {
+		if !need[i] {
+			continue
+		}
+		r := results[i]
+		if r.err != nil {
+			values, hasLast := restoreSnapshot(&statuses[i], opts[i], source.Name(), last[i], cached[i])
+			values, err := applyPolicy(&statuses[i], r.err, values, hasLast)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to load from source %s (%s): %w", source.Name(), r.duration.Round(time.Millisecond), err)
+			}
+			allValues[i] = values
+			statuses[i].Duration = r.duration
+			continue
+		}
+		allValues[i] = r.values
+		loaded[i] = true
+		// 保留快照错误，由 saveSnapshots 重试写入成功后清除
+		statuses[i] = SourceStatus{
+			Source:      statuses[i].Source,
+			Policy:      statuses[i].Policy,
+			LoadedAt:    time.Now(),
+			Duration:    r.duration,
+			SnapshotErr: statuses[i].SnapshotErr,
+		}
+	}
+
+	c, err := m.build(ctx, sources, allValues)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 快照只保存通过校验的配置，磁盘写入在锁外执行
+	saveSnapshots(sources, opts, loaded, last, allValues, statuses)
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	return m.loadLocked(ctx)
-}
+	m.config = c.config
+	m.provenance = c.provenance
+	m.unknownKeys = c.unknownKeys
+	m.loaded = true
+	c.commit()
 
-// loadLocked 内部加载方法（需要持有锁）
-func (m *Manager) loadLocked(ctx context.Context) error {
-	allValues := make([]map[string]Value, 0, len(m.sources))
+	// 只缓存本次成功加载的结果，降级的配置源保留上次的缓存
+	for i, e := range entries {
+		if loaded[i] {
+			e.values, e.cached = allValues[i], true
+		}
+		e.effective = allValues[i]
+		e.status, e.attempted = statuses[i], true
+	}
+	return oldConfig, c.config.clone(), nil
+}
 
-	for _, source := range m.sources {
-		values, err := source.Load(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to load from source %s: %w", source.Name(), err)
+// refreshSet 返回需要重新加载的配置源
+// names 为空或没有匹配的配置源时重新加载全部
+func refreshSet(sources []Source, names []string) []bool {
+	refresh := make([]bool, len(sources))
+	matched := false
+	for i, source := range sources {
+		if slices.Contains(names, source.Name()) {
+			refresh[i] = true
+			matched = true
 		}
-		allValues = append(allValues, values)
+	}
+	if !matched {
+		for i := range refresh {
+			refresh[i] = true
+		}
+	}
+	return refresh
+}
+
+// candidate 通过全部校验、等待替换的候选配置
+type candidate struct {
+	config      *configImpl
+	provenance  map[string]Provenance
+	unknownKeys []UnknownKey
+	commit      func() // 提交类型化绑定的新值
+}
+
+// build 合并各配置源的结果并构建候选配置（需要持有 loadMu，不持有 mu）
+// allValues 与 sources 一一对应，任一步骤失败时返回错误，当前配置不受影响
+func (m *Manager) build(ctx context.Context, sources []Source, allValues []map[string]Value) (*candidate, error) {
+	// 按配置源检查未知 key，此时 key 尚未合并，可以准确指出来源
+	unknown, err := m.checkUnknownKeys(sources, allValues)
+	if err != nil {
+		return nil, err
 	}
 
 	// 合并所有配置（按优先级，后面的覆盖前面的）
 	merged := m.merger.Merge(allValues...)
-	m.config = newConfigImplFromMap(merged)
 
-	return nil
+	// 先解密和解析密钥，使插值引用密钥时得到真实值；密钥明文本身不再展开
+	literal := literalKeys(merged, m.keyring != nil, len(m.secretResolvers) > 0)
+	if m.keyring != nil {
+		decrypted, err := decryptValues(merged, m.keyring)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt config: %w", err)
+		}
+		merged = decrypted
+	}
+
+	if len(m.secretResolvers) > 0 {
+		resolved, err := resolveSecrets(ctx, merged, m.secretResolvers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve secrets: %w", err)
+		}
+		merged = resolved
+	}
+
+	// 插值前标记敏感值，引用敏感值的结果同样会被标记
+	markSensitive(merged, m.sensitivePatterns, DefaultRegistry)
+
+	if m.interpolate {
+		expanded, err := interpolate(merged, literal)
+		if err != nil {
+			return nil, fmt.Errorf("failed to interpolate config: %w", err)
+		}
+		merged = expanded
+	}
+
+	var schemaErr *ValidationError
+	if m.schema != nil {
+		merged, schemaErr = applySchema(merged, m.schema)
+	}
+
+	cfg := newConfigImplFromMap(merged)
+	provenance := buildProvenance(sources, allValues, merged)
+
+	if schemaErr != nil {
+		schemaErr.annotate(provenance)
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, schemaErr)
+	}
+
+	if err := checkRequired(merged, m.requiredKeys, m.requiredPrefixes, sources); err != nil {
+		return nil, err
+	}
+
+	// 校验错误中标注每个违规值来自哪个配置源
+	if err := validateConfig(cfg, m.validators, provenance); err != nil {
+		return nil, err
+	}
+
+	// 绑定全部解码成功后才替换配置，避免部分更新
+	commit, err := decodeBindings(m.bindings, cfg, provenance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &candidate{config: cfg, provenance: provenance, unknownKeys: unknown, commit: commit}, nil
 }
 
 // Watch 启动所有配置源的监听
+// 设置了重试策略的配置源在监听启动失败时按策略重试，重试等待期间不持有 Manager 锁
 func (m *Manager) Watch() error {
 	m.mu.Lock()
-	defer m.mu.Unlock()
+	// 开启防抖时由单个 goroutine 合并所有配置源的事件
+	if m.debounceWindow > 0 && m.pending == nil {
+		m.pending = make(chan sourceEvent)
+		m.wg.Add(1)
+		go m.debounceLoop(m.debounceWindow, m.debounceMaxWait)
+	}
+	if m.recoveryInterval > 0 && !m.recovering {
+		m.recovering = true
+		m.wg.Add(1)
+		go m.recoverLoop(m.recoveryInterval)
+	}
+	sources := slices.Clone(m.sources)
+	opts := make([]sourceOptions, len(sources))
+	for i, e := range m.entries {
+		opts[i] = e.opts
+	}
+	m.mu.Unlock()
 
-	for _, source := range m.sources {
+	for i, source := range sources {
 		watcher := source.Watch()
 		if watcher == nil {
 			continue
 		}
+		if opts[i].retry != nil {
+			watcher = RetryWatcher(watcher, *opts[i].retry)
+		}
 
 		eventCh, err := watcher.Start(m.ctx)
 		if err != nil {
 			return fmt.Errorf("failed to start watcher for source %s: %w", source.Name(), err)
 		}
 
+		m.mu.Lock()
 		m.watchers = append(m.watchers, watcher)
+		m.mu.Unlock()
 
 		// 启动 goroutine 处理事件
 		m.wg.Add(1)
@@ -132,27 +402,40 @@ func (m *Manager) handleEvents(sourceName string, eventCh <-chan Event) {
 				continue
 			}
 
-			// 重新加载配置
-			m.mu.Lock()
-			oldConfig := m.config.clone()
-			if err := m.loadLocked(m.ctx); err != nil {
-				m.mu.Unlock()
-				m.notifyChange(Event{
-					Type:      EventTypeError,
-					Source:    sourceName,
-					Timestamp: event.Timestamp,
-					Error:     err,
-				}, nil, nil)
+			if m.pending != nil {
+				select {
+				case m.pending <- sourceEvent{source: sourceName, event: event}:
+				case <-m.ctx.Done():
+					return
+				}
 				continue
 			}
-			newConfig := m.config.clone()
-			m.mu.Unlock()
 
-			m.notifyChange(event, oldConfig, newConfig)
+			m.reload([]string{sourceName}, event)
 		}
 	}
 }
 
+// reload 重新加载 sources 中的配置源并通知回调和订阅者
+// 加载失败时发送错误事件，保留当前配置
+func (m *Manager) reload(sources []string, event Event) {
+	// 只重新加载发出事件的配置源，防抖合并的事件可能来自多个配置源
+	oldConfig, newConfig, err := m.load(m.ctx, sources)
+	if err != nil {
+		m.notifyChange(Event{
+			Type:      EventTypeError,
+			Source:    event.Source,
+			Timestamp: event.Timestamp,
+			Error:     err,
+		}, nil, nil)
+		return
+	}
+
+	changes := diff(oldConfig.data, newConfig.data)
+	m.notifyChange(withChanges(event, changes), oldConfig, newConfig)
+	m.notifySubscribers(changes)
+}
+
 // OnChange 注册配置变更回调
 func (m *Manager) OnChange(callback ChangeCallback) {
 	m.mu.Lock()
@@ -217,10 +500,27 @@ func NewDefaultMerger() Merger {
 // defaultMerger 默认合并器实现
 type defaultMerger struct{}
 
+// Merge 按顺序合并，后面的覆盖前面的
+// 前面配置源中 key 为数组而后面的配置源设置了 key 本身时，前面展开的下标子 key（key.0、key.1.host 等）一并被覆盖，
+// 例如环境变量 APP_HOSTS=c,d 覆盖文件中的 hosts 数组时，文件展开的 hosts.0、hosts.1 不再生效；
+// 其他子 key 不受影响，APP_LOG=debug 不会覆盖文件中的 log.level
 func (m *defaultMerger) Merge(maps ...map[string]Value) map[string]Value {
 	result := make(map[string]Value)
 
 	for _, configMap := range maps {
+		arrays := make(map[string]bool)
+		for k := range configMap {
+			if prev, ok := result[k]; ok && isArray(prev.Raw()) {
+				arrays[k] = true
+			}
+		}
+		if len(arrays) > 0 {
+			for k := range result {
+				if hasIndexedParentIn(k, arrays) {
+					delete(result, k)
+				}
+			}
+		}
 		for k, v := range configMap {
 			result[k] = v
 		}
@@ -228,3 +528,27 @@ func (m *defaultMerger) Merge(maps ...map[string]Value) map[string]Value {
 
 	return result
 }
+
+// isArray 判断原始值是否为数组
+func isArray(raw any) bool {
+	switch raw.(type) {
+	case []any, []string:
+		return true
+	default:
+		return false
+	}
+}
+
+// hasIndexedParentIn 判断 key 是否为 parents 中某个 key 的下标子 key，例如 hosts.0 或 servers.1.host
+func hasIndexedParentIn(key string, parents map[string]bool) bool {
+	for i := strings.LastIndexByte(key, '.'); i > 0; i = strings.LastIndexByte(key[:i], '.') {
+		if !parents[key[:i]] {
+			continue
+		}
+		index, _, _ := strings.Cut(key[i+1:], ".")
+		if _, err := strconv.Atoi(index); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -36,6 +37,17 @@ func (m *mockSource) Watch() Watcher {
 	return m.watcher
 }
 
+// countingSource 统计 Load 调用次数的测试配置源
+type countingSource struct {
+	*mockSource
+	loads atomic.Int32
+}
+
+func (s *countingSource) Load(ctx context.Context) (map[string]Value, error) {
+	s.loads.Add(1)
+	return s.mockSource.Load(ctx)
+}
+
 // mockWatcher 测试用的 mock watcher
 type mockWatcher struct {
 	eventCh chan Event
@@ -281,6 +293,49 @@ func TestManager_Watch(t *testing.T) {
 		m.Close()
 	})
 
+	t.Run("change_set", func(t *testing.T) {
+		m := NewManager()
+
+		watcher := &mockWatcher{eventCh: make(chan Event, 10)}
+		source := &mockSource{
+			name:    "test",
+			data:    map[string]Value{"a": NewValue("1"), "b": NewValue("2")},
+			watcher: watcher,
+		}
+		m.AddSource(source)
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		events := make(chan Event, 10)
+		m.OnChange(func(event Event, oldConfig, newConfig Config) {
+			events <- event
+		})
+		if err := m.Watch(); err != nil {
+			t.Fatalf("Watch() error = %v", err)
+		}
+		defer m.Close()
+
+		// 配置源报告的 Keys 与实际变化无关
+		source.data = map[string]Value{"a": NewValue("1"), "b": NewValue("3"), "c": NewValue("4")}
+		watcher.eventCh <- Event{Type: EventTypeReload, Source: "test", Keys: []string{"a", "b", "c"}}
+
+		select {
+		case event := <-events:
+			if event.Type != EventTypeUpdate {
+				t.Errorf("Type = %v, want update", event.Type)
+			}
+			if len(event.Keys) != 2 || event.Keys[0] != "b" || event.Keys[1] != "c" {
+				t.Errorf("Keys = %v, want [b c]", event.Keys)
+			}
+			if len(event.Changes.Added) != 1 || len(event.Changes.Modified) != 1 {
+				t.Errorf("Changes = %+v", event.Changes)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for callback")
+		}
+	})
+
 	t.Run("no_watcher", func(t *testing.T) {
 		m := NewManager()
 
@@ -310,6 +365,224 @@ func TestManager_Watch(t *testing.T) {
 	})
 }
 
+// blockingSource Load 在 release 关闭前阻塞的测试配置源
+type blockingSource struct {
+	*mockSource
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingSource) Load(ctx context.Context) (map[string]Value, error) {
+	select {
+	case s.started <- struct{}{}:
+	default:
+	}
+	<-s.release
+	return s.mockSource.Load(ctx)
+}
+
+func TestRefreshSet(t *testing.T) {
+	sources := []Source{&mockSource{name: "file"}, &mockSource{name: "consul"}, &mockSource{name: "env"}}
+
+	tests := []struct {
+		name  string
+		names []string
+		want  []bool
+	}{
+		{"all", nil, []bool{true, true, true}},
+		{"single", []string{"consul"}, []bool{false, true, false}},
+		{"coalesced", []string{"file", "env"}, []bool{true, false, true}},
+		{"unknown_name_reloads_all", []string{"postgres"}, []bool{true, true, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := refreshSet(sources, tt.names)
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("refreshSet() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestManager_IncrementalReload(t *testing.T) {
+	t.Run("reload_event_source_only", func(t *testing.T) {
+		watcher := &mockWatcher{eventCh: make(chan Event, 10)}
+		file := &countingSource{mockSource: &mockSource{
+			name:     "file",
+			priority: 60,
+			data:     map[string]Value{"a": NewValue("1")},
+			watcher:  watcher,
+		}}
+		consul := &countingSource{mockSource: &mockSource{
+			name:     "consul",
+			priority: 80,
+			data:     map[string]Value{"b": NewValue("2")},
+		}}
+
+		m := NewManager()
+		m.AddSource(file, consul)
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		events := make(chan Event, 10)
+		m.OnChange(func(event Event, oldConfig, newConfig Config) { events <- event })
+		if err := m.Watch(); err != nil {
+			t.Fatalf("Watch() error = %v", err)
+		}
+		defer m.Close()
+
+		file.data = map[string]Value{"a": NewValue("10")}
+		watcher.eventCh <- Event{Type: EventTypeUpdate, Source: "file"}
+
+		select {
+		case <-events:
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for callback")
+		}
+		if file.loads.Load() != 2 || consul.loads.Load() != 1 {
+			t.Errorf("loads file=%d consul=%d, want 2 and 1", file.loads.Load(), consul.loads.Load())
+		}
+		cfg := m.Config()
+		if cfg.GetString("a", "") != "10" || cfg.GetString("b", "") != "2" {
+			t.Errorf("config = %v, want reloaded file merged with cached consul", cfg)
+		}
+
+		// 显式 Load 重新加载全部配置源
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if consul.loads.Load() != 2 {
+			t.Errorf("consul loads = %d, want 2 after full Load", consul.loads.Load())
+		}
+	})
+
+	t.Run("failed_reload_keeps_cache", func(t *testing.T) {
+		file := &countingSource{mockSource: &mockSource{name: "file", data: map[string]Value{"a": NewValue("1")}}}
+		consul := &mockSource{name: "consul", priority: 80, data: map[string]Value{"b": NewValue("2")}}
+
+		m := NewManager()
+		m.AddSource(file, consul)
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		consul.loadErr = errors.New("unreachable")
+		if _, _, err := m.load(context.Background(), []string{"consul"}); err == nil {
+			t.Fatal("load() should fail")
+		}
+		consul.loadErr = nil
+		consul.data = map[string]Value{"b": NewValue("3")}
+
+		if _, _, err := m.load(context.Background(), []string{"file"}); err != nil {
+			t.Fatalf("load() error = %v", err)
+		}
+		if got := m.Config().GetString("b", ""); got != "2" {
+			t.Errorf("b = %q, want cached value 2", got)
+		}
+	})
+
+	t.Run("load_outside_lock", func(t *testing.T) {
+		remote := &blockingSource{
+			mockSource: &mockSource{name: "consul", data: map[string]Value{"b": NewValue("2")}},
+			started:    make(chan struct{}, 1),
+			release:    make(chan struct{}),
+		}
+
+		m := NewManager()
+		m.AddSource(remote)
+
+		done := make(chan error, 1)
+		go func() { done <- m.Load(context.Background()) }()
+		<-remote.started
+
+		read := make(chan struct{})
+		go func() {
+			m.Config()
+			m.Explain("b")
+			close(read)
+		}()
+		select {
+		case <-read:
+		case <-time.After(time.Second):
+			t.Error("Config() should not block while a source is loading")
+		}
+
+		close(remote.release)
+		if err := <-done; err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+	})
+}
+
+// valueSource 值接收者实现的配置源，包含 map 字段因而不可作为 map key
+type valueSource struct {
+	name string
+	data map[string]Value
+}
+
+func (s valueSource) Name() string                                       { return s.name }
+func (s valueSource) Priority() int                                      { return 0 }
+func (s valueSource) Load(ctx context.Context) (map[string]Value, error) { return s.data, nil }
+func (s valueSource) Watch() Watcher                                     { return nil }
+
+func TestManager_UnhashableSource(t *testing.T) {
+	m := NewManager()
+	m.AddSource(valueSource{name: "static", data: map[string]Value{"a": NewValue("1")}})
+	m.AddSourceWith(valueSource{name: "optional", data: map[string]Value{"b": NewValue("2")}}, WithPolicy(PolicyOptional))
+
+	if err := m.Load(context.Background()); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := m.Config().GetString("b", ""); got != "2" {
+		t.Errorf("b = %q, want 2", got)
+	}
+	if got := len(m.SourceStatus()); got != 2 {
+		t.Errorf("SourceStatus() has %d entries, want 2", got)
+	}
+}
+
+func TestManager_BuildOutsideLock(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	resolver := SecretResolverFunc(func(ctx context.Context, ref string) (string, error) {
+		started <- struct{}{}
+		<-release
+		return "s3cret", nil
+	})
+
+	m := NewManager(WithSecretResolver("slow", resolver))
+	m.AddSource(&mockSource{name: "file", data: map[string]Value{"db.password": NewValue("secret://slow/db")}})
+
+	done := make(chan error, 1)
+	go func() { done <- m.Load(context.Background()) }()
+	<-started
+
+	read := make(chan struct{})
+	go func() {
+		m.Config()
+		m.SourceStatus()
+		close(read)
+	}()
+	select {
+	case <-read:
+	case <-time.After(time.Second):
+		t.Error("Config() should not block while secrets are resolved")
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := m.Config().GetString("db.password", ""); got != "s3cret" {
+		t.Errorf("db.password = %q, want resolved secret", got)
+	}
+}
+
 func TestManager_OnChange(t *testing.T) {
 	m := NewManager()
 
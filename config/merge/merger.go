@@ -18,14 +18,7 @@ func NewDefaultMerger() *DefaultMerger {
 	return &DefaultMerger{}
 }
 
+// Merge 与 Manager 的默认合并器一致：后面的配置源设置了 key 本身时，前面配置源中 key 数组展开的下标子 key 一并被覆盖
 func (m *DefaultMerger) Merge(maps ...map[string]config.Value) map[string]config.Value {
-	result := make(map[string]config.Value)
-
-	for _, configMap := range maps {
-		for k, v := range configMap {
-			result[k] = v
-		}
-	}
-
-	return result
+	return config.NewDefaultMerger().Merge(maps...)
 }
@@ -55,3 +55,49 @@ func TestDefaultMerger_EmptyMaps(t *testing.T) {
 		t.Errorf("Expected empty result, got %d items", len(result))
 	}
 }
+
+func TestDefaultMerger_ParentOverride(t *testing.T) {
+	merger := NewDefaultMerger()
+
+	// 文件中的数组同时展开为下标子 key
+	file := map[string]config.Value{
+		"hosts":          config.NewValueFromInterface([]any{"a", "b"}),
+		"hosts.0":        config.NewValue("a"),
+		"hosts.1":        config.NewValue("b"),
+		"servers.0.host": config.NewValue("a.local"),
+		"servers.0.port": config.NewValue("8001"),
+		"log.level":      config.NewValue("info"),
+	}
+	env := map[string]config.Value{
+		"hosts":          config.NewValue("c,d,e"),    // 整体覆盖数组
+		"servers.0.host": config.NewValue("env.host"), // 只覆盖单个元素
+		"log":            config.NewValue("debug"),    // 非数组，不影响子 key
+	}
+
+	result := merger.Merge(file, env)
+
+	tests := []struct {
+		key      string
+		expected string
+		exists   bool
+	}{
+		{"hosts", "c,d,e", true},
+		{"hosts.0", "", false},
+		{"hosts.1", "", false},
+		{"servers.0.host", "env.host", true},
+		{"servers.0.port", "8001", true},
+		{"log", "debug", true},
+		{"log.level", "info", true},
+	}
+
+	for _, tt := range tests {
+		val, ok := result[tt.key]
+		if ok != tt.exists {
+			t.Errorf("Key %s exists = %v, want %v", tt.key, ok, tt.exists)
+			continue
+		}
+		if ok && val.String() != tt.expected {
+			t.Errorf("Key %s: expected %s, got %s", tt.key, tt.expected, val.String())
+		}
+	}
+}
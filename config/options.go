@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 // ManagerOption 配置管理器选项
 type ManagerOption func(*Manager)
 
@@ -10,6 +12,147 @@ func WithMerger(merger Merger) ManagerOption {
 	}
 }
 
+// WithInterpolation 启用变量插值
+// 所有配置源合并后展开 ${key}、${env:NAME}、${key:-default} 形式的引用
+func WithInterpolation() ManagerOption {
+	return func(m *Manager) {
+		m.interpolate = true
+	}
+}
+
+// WithSecretResolver 注册密钥解析器
+// 值为 secret://<name>/<ref> 的配置在合并后交给 name 对应的解析器解析
+// 未注册任何解析器时，密钥引用按普通字符串处理
+func WithSecretResolver(name string, resolver SecretResolver) ManagerOption {
+	return func(m *Manager) {
+		m.secretResolvers[name] = resolver
+	}
+}
+
+// WithKeyring 设置用于解密 enc:v1:... 加密值的密钥环
+// 未设置密钥环时，加密值按普通字符串处理
+func WithKeyring(keyring *Keyring) ManagerOption {
+	return func(m *Manager) {
+		m.keyring = keyring
+	}
+}
+
+// WithConfigValidator 注册配置校验函数
+// 每次加载或热更新时，候选配置通过所有校验后才会替换当前配置
+// 校验失败时保留上一次的有效配置，热更新会发送描述违规项的错误事件
+func WithConfigValidator(validators ...ConfigValidator) ManagerOption {
+	return func(m *Manager) {
+		m.validators = append(m.validators, validators...)
+	}
+}
+
+// WithSchema 设置 JSON Schema
+// 每次加载或热更新时，先按 schema 类型转换字符串值并填充默认值，再校验重建后的嵌套配置
+// 校验失败时本次加载失败，保留上一次的有效配置
+func WithSchema(schema *Schema) ManagerOption {
+	return func(m *Manager) {
+		m.schema = schema
+	}
+}
+
+// WithSensitiveKeys 追加敏感 key 模式（path.Match 语法，不区分大小写）
+// 匹配的配置值在 fmt 输出中显示为 ******，默认模式见 DefaultSensitivePatterns
+func WithSensitiveKeys(patterns ...string) ManagerOption {
+	return func(m *Manager) {
+		m.sensitivePatterns = append(m.sensitivePatterns, patterns...)
+	}
+}
+
+// WithStrictKeys 设置未知 key 检测模式
+// 已知 key 来自 DefaultRegistry、WithKnownKeys 和 Bind 的结构体类型，未声明任何已知 key 时不检测
+// StrictWarn 通过 Manager.UnknownKeys 报告，StrictError 使 Load 返回 *UnknownKeysError
+func WithStrictKeys(mode StrictMode) ManagerOption {
+	return func(m *Manager) {
+		m.strictMode = mode
+	}
+}
+
+// WithKnownKeys 声明已知 key，支持 path.Match 模式（如 plugins.*）
+// 结构体可通过 KeysOf 推导：WithKnownKeys(KeysOf[AppConfig]("")...)
+func WithKnownKeys(keys ...string) ManagerOption {
+	return func(m *Manager) {
+		m.knownKeys = append(m.knownKeys, keys...)
+	}
+}
+
+// WithRequiredKeys 声明必需配置键
+// 合并后缺失或值为空字符串时 Load 失败，返回列出所有缺失项的 *MissingKeysError
+// 错误中包含实现 KeyLocator 的配置源（如环境变量名、Consul 路径）可以提供该 key 的位置
+func WithRequiredKeys(keys ...string) ManagerOption {
+	return func(m *Manager) {
+		m.requiredKeys = append(m.requiredKeys, keys...)
+	}
+}
+
+// WithRequiredPrefixes 声明必需配置前缀，前缀下至少存在一个非空配置
+func WithRequiredPrefixes(prefixes ...string) ManagerOption {
+	return func(m *Manager) {
+		m.requiredPrefixes = append(m.requiredPrefixes, prefixes...)
+	}
+}
+
+// WithDebounce 合并突发的变更事件
+// 事件到达后等待 window，期间来自任意配置源的新事件会推迟重载并合并为一次重载和一次回调通知
+// 从批次第一个事件起最多等待 maxWait，maxWait <= 0 时默认为 window 的 10 倍；window <= 0 表示不防抖
+func WithDebounce(window, maxWait time.Duration) ManagerOption {
+	return func(m *Manager) {
+		if maxWait <= 0 {
+			maxWait = defaultMaxWaitFactor * window
+		}
+		m.debounceWindow = window
+		m.debounceMaxWait = maxWait
+	}
+}
+
+// WithRecoveryInterval 设置降级配置源的重新探测间隔
+// Watch 启动后每隔 interval 重新加载降级的配置源，恢复后切回实时数据并通知回调；
+// 没有 Watcher 的配置源（如 PostgreSQL）依赖它恢复，未设置时需要调用方再次调用 Load
+func WithRecoveryInterval(interval time.Duration) ManagerOption {
+	return func(m *Manager) {
+		m.recoveryInterval = interval
+	}
+}
+
+// SourceOption 单个配置源的加载选项，用于 Manager.AddSourceWith
+type SourceOption func(*sourceOptions)
+
+// WithPolicy 设置配置源加载失败时的处理策略，默认为 PolicyRequired
+// 降级的配置源在之后每次加载时都会重新尝试，可通过 Manager.DegradedSources 查询
+func WithPolicy(policy SourcePolicy) SourceOption {
+	return func(o *sourceOptions) {
+		o.policy = policy
+	}
+}
+
+// WithSnapshot 将配置源每次成功加载的结果保存到快照缓存
+// 配置源策略为 PolicyFallback 且内存中没有上次结果（如启动时）加载失败时，从快照恢复并标记为过期
+func WithSnapshot(cache *SnapshotCache) SourceOption {
+	return func(o *sourceOptions) {
+		o.snapshot = cache
+	}
+}
+
+// WithTimeout 设置配置源单次加载的超时时间，0 表示不限制
+// 所有配置源并发加载，超时的配置源按其失败策略处理
+func WithTimeout(timeout time.Duration) SourceOption {
+	return func(o *sourceOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithRetry 设置配置源加载和监听启动失败时的重试策略
+// 重试全部失败后按配置源的失败策略处理；WithTimeout 限制的是每次尝试的耗时
+func WithRetry(policy RetryPolicy) SourceOption {
+	return func(o *sourceOptions) {
+		o.retry = &policy
+	}
+}
+
 // Merger 定义配置合并策略接口
 type Merger interface {
 	// Merge 合并多个配置映射
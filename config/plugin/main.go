@@ -21,7 +21,28 @@ var (
 
 // 导出 Manager 选项
 var (
-	WithMerger = config.WithMerger
+	WithMerger           = config.WithMerger
+	WithInterpolation    = config.WithInterpolation
+	WithSecretResolver   = config.WithSecretResolver
+	WithKeyring          = config.WithKeyring
+	WithSensitiveKeys    = config.WithSensitiveKeys
+	WithConfigValidator  = config.WithConfigValidator
+	WithSchema           = config.WithSchema
+	WithStrictKeys       = config.WithStrictKeys
+	WithKnownKeys        = config.WithKnownKeys
+	WithRequiredKeys     = config.WithRequiredKeys
+	WithRequiredPrefixes = config.WithRequiredPrefixes
+	WithDebounce         = config.WithDebounce
+	WithRecoveryInterval = config.WithRecoveryInterval
+)
+
+// 导出配置源选项
+var (
+	WithPolicy       = config.WithPolicy
+	WithSnapshot     = config.WithSnapshot
+	WithTimeout      = config.WithTimeout
+	WithRetry        = config.WithRetry
+	NewSnapshotCache = config.NewSnapshotCache
 )
 
 // 包装函数：返回 config.Source 接口而非具体类型
@@ -91,6 +112,13 @@ const (
 	EventTypeError   = config.EventTypeError
 )
 
+// SourcePolicy 枚举
+const (
+	PolicyRequired = config.PolicyRequired
+	PolicyOptional = config.PolicyOptional
+	PolicyFallback = config.PolicyFallback
+)
+
 // Source 优先级常量
 const (
 	EnvSourcePriority      = env.DefaultPriority
@@ -0,0 +1,131 @@
+package config
+
+import "time"
+
+// SourcePolicy 配置源加载失败时的处理策略
+type SourcePolicy int
+
+const (
+	PolicyRequired SourcePolicy = iota // 加载失败时 Load 失败（默认）
+	PolicyOptional                     // 跳过该配置源并报告降级
+	PolicyFallback                     // 沿用上次成功加载的结果并报告降级，没有上次结果时跳过
+)
+
+func (p SourcePolicy) String() string {
+	switch p {
+	case PolicyRequired:
+		return "required"
+	case PolicyOptional:
+		return "optional"
+	case PolicyFallback:
+		return "fallback"
+	default:
+		return "unknown"
+	}
+}
+
+// sourceOptions 单个配置源的加载选项
+type sourceOptions struct {
+	policy   SourcePolicy
+	snapshot *SnapshotCache
+	timeout  time.Duration
+	retry    *RetryPolicy
+}
+
+// SourceStatus 配置源最近一次加载的状态
+type SourceStatus struct {
+	Source   string        // 配置源名称
+	Policy   SourcePolicy  // 失败策略
+	Degraded bool          // 最近一次加载失败，配置源被跳过或沿用了上次的结果
+	Fallback bool          // 降级时沿用了上次成功加载的结果
+	Stale    bool          // 降级时使用的是磁盘快照中的结果
+	Err      error         // 降级原因
+	LoadedAt time.Time     // 最近一次成功加载的时间，使用快照时为快照保存时间
+	Duration time.Duration // 最近一次加载耗时
+
+	SnapshotErr error // 读写快照失败的原因，保留到下一次写入快照成功为止
+}
+
+// applyPolicy 按失败策略处理配置源加载错误，返回本次参与合并的配置
+// PolicyRequired 返回原错误，其他策略更新 status 为降级状态
+func applyPolicy(status *SourceStatus, err error, last map[string]Value, hasLast bool) (map[string]Value, error) {
+	if status.Policy == PolicyRequired {
+		return nil, err
+	}
+
+	status.Degraded = true
+	status.Err = err
+	status.Fallback = status.Policy == PolicyFallback && hasLast
+	if status.Fallback {
+		return last, nil
+	}
+	return nil, nil
+}
+
+// SourceStatus 返回所有已加载配置源的状态，按优先级从低到高排列
+func (m *Manager) SourceStatus() []SourceStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]SourceStatus, 0, len(m.entries))
+	for _, e := range m.entries {
+		if e.attempted {
+			result = append(result, e.status)
+		}
+	}
+	return result
+}
+
+// recoverLoop 定期重新加载降级的配置源
+// 配置源仍不可用时配置不变，不通知回调；恢复后按普通重载通知回调和订阅者
+func (m *Manager) recoverLoop(interval time.Duration) {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		degraded := m.DegradedSources()
+		if len(degraded) == 0 {
+			continue
+		}
+		names := make([]string, len(degraded))
+		for i, status := range degraded {
+			names[i] = status.Source
+		}
+
+		// 与防抖合并的事件一致，涉及多个配置源时 Source 为空
+		event := Event{Type: EventTypeReload, Timestamp: time.Now()}
+		if len(names) == 1 {
+			event.Source = names[0]
+		}
+
+		oldConfig, newConfig, err := m.load(m.ctx, names)
+		if err != nil {
+			event.Type, event.Error = EventTypeError, err
+			m.notifyChange(event, nil, nil)
+			continue
+		}
+		if changes := diff(oldConfig.data, newConfig.data); !changes.Empty() {
+			m.notifyChange(withChanges(event, changes), oldConfig, newConfig)
+			m.notifySubscribers(changes)
+		}
+	}
+}
+
+// DegradedSources 返回最近一次加载中被跳过或沿用上次结果的配置源
+func (m *Manager) DegradedSources() []SourceStatus {
+	var result []SourceStatus
+	for _, status := range m.SourceStatus() {
+		if status.Degraded {
+			result = append(result, status)
+		}
+	}
+	return result
+}
@@ -0,0 +1,204 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestApplyPolicy(t *testing.T) {
+	loadErr := errors.New("connection refused")
+	last := map[string]Value{"a": NewValue("1")}
+
+	tests := []struct {
+		name         string
+		policy       SourcePolicy
+		hasLast      bool
+		wantErr      bool
+		wantValues   bool
+		wantFallback bool
+	}{
+		{"required", PolicyRequired, true, true, false, false},
+		{"optional", PolicyOptional, true, false, false, false},
+		{"fallback_with_last", PolicyFallback, true, false, true, true},
+		{"fallback_without_last", PolicyFallback, false, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := SourceStatus{Source: "consul", Policy: tt.policy}
+			values, err := applyPolicy(&status, loadErr, last, tt.hasLast)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if status.Degraded {
+					t.Error("required source should not be marked degraded")
+				}
+				return
+			}
+			if (values != nil) != tt.wantValues {
+				t.Errorf("values = %v, want last values %v", values, tt.wantValues)
+			}
+			if !status.Degraded || status.Fallback != tt.wantFallback || !errors.Is(status.Err, loadErr) {
+				t.Errorf("status = %+v", status)
+			}
+		})
+	}
+}
+
+func TestManager_SourcePolicy(t *testing.T) {
+	newSources := func() (*mockSource, *mockSource) {
+		file := &mockSource{name: "file", priority: 60, data: map[string]Value{"a": NewValue("file")}}
+		consul := &mockSource{name: "consul", priority: 80, data: map[string]Value{"a": NewValue("consul"), "b": NewValue("2")}}
+		return file, consul
+	}
+
+	t.Run("required_fails_load", func(t *testing.T) {
+		file, consul := newSources()
+		consul.loadErr = errors.New("unreachable")
+
+		m := NewManager()
+		m.AddSource(file).AddSourceWith(consul, WithPolicy(PolicyRequired))
+		if err := m.Load(context.Background()); err == nil {
+			t.Error("Load() should fail when a required source fails")
+		}
+	})
+
+	t.Run("optional_skipped", func(t *testing.T) {
+		file, consul := newSources()
+		consul.loadErr = errors.New("unreachable")
+
+		m := NewManager()
+		m.AddSource(file).AddSourceWith(consul, WithPolicy(PolicyOptional))
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if got := m.Config().GetString("a", ""); got != "file" {
+			t.Errorf("a = %q, want value from file", got)
+		}
+
+		degraded := m.DegradedSources()
+		if len(degraded) != 1 || degraded[0].Source != "consul" || degraded[0].Policy != PolicyOptional {
+			t.Fatalf("DegradedSources() = %+v", degraded)
+		}
+		if degraded[0].Fallback || !degraded[0].LoadedAt.IsZero() {
+			t.Errorf("optional source without previous load should not fall back: %+v", degraded[0])
+		}
+
+		// 恢复后下一次加载重新使用该配置源
+		consul.loadErr = nil
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if len(m.DegradedSources()) != 0 || m.Config().GetString("a", "") != "consul" {
+			t.Errorf("recovered source should be used, degraded = %+v", m.DegradedSources())
+		}
+	})
+
+	t.Run("fallback_to_last_known", func(t *testing.T) {
+		file, consul := newSources()
+
+		m := NewManager()
+		m.AddSource(file).AddSourceWith(consul, WithPolicy(PolicyFallback))
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		consul.loadErr = errors.New("unreachable")
+		consul.data = map[string]Value{"a": NewValue("changed")}
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if got := m.Config().GetString("b", ""); got != "2" {
+			t.Errorf("b = %q, want last known value 2", got)
+		}
+
+		statuses := m.SourceStatus()
+		if len(statuses) != 2 || statuses[0].Degraded || !statuses[1].Degraded || !statuses[1].Fallback {
+			t.Errorf("SourceStatus() = %+v", statuses)
+		}
+		if statuses[1].LoadedAt.IsZero() || time.Since(statuses[1].LoadedAt) > time.Minute {
+			t.Errorf("LoadedAt = %v, want time of last successful load", statuses[1].LoadedAt)
+		}
+	})
+
+	t.Run("degraded_reused_on_incremental_reload", func(t *testing.T) {
+		file, consul := newSources()
+		counting := &countingSource{mockSource: consul}
+
+		m := NewManager()
+		m.AddSource(file).AddSourceWith(counting, WithPolicy(PolicyFallback))
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		consul.loadErr = errors.New("unreachable")
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		// 其他配置源的事件不重试降级的配置源，沿用上次参与合并的结果
+		loads := counting.loads.Load()
+		file.data = map[string]Value{"a": NewValue("file"), "c": NewValue("3")}
+		if _, _, err := m.load(context.Background(), []string{"file"}); err != nil {
+			t.Fatalf("load() error = %v", err)
+		}
+		if got := counting.loads.Load(); got != loads {
+			t.Errorf("consul loads = %d, want %d", got, loads)
+		}
+		cfg := m.Config()
+		if cfg.GetString("b", "") != "2" || cfg.GetString("c", "") != "3" {
+			t.Errorf("config = %v, want fallback b and new c", cfg)
+		}
+		if degraded := m.DegradedSources(); len(degraded) != 1 || !degraded[0].Fallback {
+			t.Errorf("DegradedSources() = %+v, want consul still degraded", degraded)
+		}
+
+		// 显式 Load 重试降级的配置源
+		consul.loadErr = nil
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if len(m.DegradedSources()) != 0 {
+			t.Errorf("degraded source should be retried by Load, got %+v", m.DegradedSources())
+		}
+	})
+}
+
+func TestManager_WithRecoveryInterval(t *testing.T) {
+	// 没有 Watcher 的配置源启动时不可达，恢复后由定期探测切回实时数据
+	postgres := &flakySource{
+		mockSource: &mockSource{name: "postgres", priority: 70, data: map[string]Value{"a": NewValue("live")}},
+		failures:   1,
+	}
+
+	m := NewManager(WithRecoveryInterval(20 * time.Millisecond))
+	m.AddSourceWith(postgres, WithPolicy(PolicyOptional))
+	if err := m.Load(context.Background()); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(m.DegradedSources()) != 1 {
+		t.Fatalf("DegradedSources() = %+v, want postgres", m.DegradedSources())
+	}
+
+	events := make(chan Event, 10)
+	m.OnChange(func(event Event, oldConfig, newConfig Config) { events <- event })
+	if err := m.Watch(); err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer m.Close()
+
+	select {
+	case event := <-events:
+		if event.Source != "postgres" || !slices.Equal(event.Keys, []string{"a"}) {
+			t.Errorf("event = %+v, want change of a from postgres", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for recovery")
+	}
+	if got := m.Config().GetString("a", ""); got != "live" || len(m.DegradedSources()) != 0 {
+		t.Errorf("a = %q, degraded = %+v, want recovered source", got, m.DegradedSources())
+	}
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Origin 某个配置源为 key 提供的值
+type Origin struct {
+	Source   string // 配置源名称
+	Priority int    // 配置源优先级
+	Value    Value  // 配置源提供的原始值（解密、插值之前）
+}
+
+// Provenance 配置键的来源信息
+type Provenance struct {
+	Key        string
+	Value      Value    // 最终生效的值
+	Source     string   // 胜出的配置源名称
+	Priority   int      // 胜出的配置源优先级
+	Raw        Value    // 胜出配置源提供的原始值
+	Overridden []Origin // 被覆盖的值，按优先级从高到低排列
+}
+
+// String 返回便于排查问题的来源描述，敏感值已脱敏
+func (p Provenance) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s=%v (source: %s, priority: %d)", p.Key, p.Value, p.Source, p.Priority)
+	for _, o := range p.Overridden {
+		fmt.Fprintf(&b, "\n  overrides %s (priority: %d): %v", o.Source, o.Priority, o.Value)
+	}
+	return b.String()
+}
+
+// buildProvenance 根据各配置源的原始值记录每个 key 的来源
+// sources 与 values 一一对应，按优先级从低到高排列；只记录最终配置中存在的 key
+// 最终值为敏感值时，被覆盖的值同样标记为敏感
+func buildProvenance(sources []Source, values []map[string]Value, final map[string]Value) map[string]Provenance {
+	result := make(map[string]Provenance, len(final))
+
+	for key, val := range final {
+		var origins []Origin
+		for i := len(values) - 1; i >= 0; i-- {
+			raw, ok := values[i][key]
+			if !ok {
+				continue
+			}
+			if val.Sensitive() {
+				raw = raw.AsSensitive()
+			}
+			origins = append(origins, Origin{
+				Source:   sources[i].Name(),
+				Priority: sources[i].Priority(),
+				Value:    raw,
+			})
+		}
+		if len(origins) == 0 {
+			continue
+		}
+
+		result[key] = Provenance{
+			Key:        key,
+			Value:      val,
+			Source:     origins[0].Source,
+			Priority:   origins[0].Priority,
+			Raw:        origins[0].Value,
+			Overridden: origins[1:],
+		}
+	}
+
+	return result
+}
+
+// Explain 返回配置键的来源信息：胜出的配置源及其覆盖的值
+// key 不存在或不由任何配置源直接提供时返回 false
+func (m *Manager) Explain(key string) (Provenance, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	p, ok := m.provenance[key]
+	return p, ok
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestManager_Explain(t *testing.T) {
+	file := &mockSource{
+		name:     "file",
+		priority: 60,
+		data: map[string]Value{
+			"database.host":     NewValue("localhost"),
+			"database.port":     NewValue("5432"),
+			"database.password": NewValue("file-pass"),
+		},
+	}
+	consul := &mockSource{
+		name:     "consul",
+		priority: 80,
+		data: map[string]Value{
+			"database.host":     NewValue("consul.db"),
+			"database.password": NewValue("consul-pass"),
+		},
+	}
+	env := &mockSource{
+		name:     "env",
+		priority: 100,
+		data:     map[string]Value{"database.host": NewValue("env.db")},
+	}
+
+	m := NewManager()
+	m.AddSource(env, file, consul)
+
+	if _, ok := m.Explain("database.host"); ok {
+		t.Error("Explain() before Load() should return false")
+	}
+	if err := m.Load(context.Background()); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	t.Run("overridden", func(t *testing.T) {
+		p, ok := m.Explain("database.host")
+		if !ok {
+			t.Fatal("Explain() should find database.host")
+		}
+		if p.Source != "env" || p.Priority != 100 || p.Value.String() != "env.db" {
+			t.Errorf("winner = %s/%d/%s, want env/100/env.db", p.Source, p.Priority, p.Value)
+		}
+		if len(p.Overridden) != 2 {
+			t.Fatalf("Overridden = %v, want 2 entries", p.Overridden)
+		}
+		if p.Overridden[0].Source != "consul" || p.Overridden[0].Value.String() != "consul.db" {
+			t.Errorf("Overridden[0] = %+v, want consul", p.Overridden[0])
+		}
+		if p.Overridden[1].Source != "file" || p.Overridden[1].Value.String() != "localhost" {
+			t.Errorf("Overridden[1] = %+v, want file", p.Overridden[1])
+		}
+	})
+
+	t.Run("single_source", func(t *testing.T) {
+		p, ok := m.Explain("database.port")
+		if !ok || p.Source != "file" || len(p.Overridden) != 0 {
+			t.Errorf("Explain() = %+v, %v", p, ok)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		if _, ok := m.Explain("nope"); ok {
+			t.Error("Explain() should return false for missing key")
+		}
+	})
+
+	t.Run("string_redacts_sensitive", func(t *testing.T) {
+		p, _ := m.Explain("database.password")
+		s := p.String()
+		if strings.Contains(s, "file-pass") || strings.Contains(s, "consul-pass") {
+			t.Errorf("String() leaks sensitive values:\n%s", s)
+		}
+		if !strings.Contains(s, "source: consul") || !strings.Contains(s, "overrides file") {
+			t.Errorf("String() = %q, should describe sources", s)
+		}
+	})
+}
+
+func TestManager_Explain_Pipeline(t *testing.T) {
+	k := newTestKeyring(t, "k1")
+	enc, _ := k.Encrypt("p@ss")
+
+	m := NewManager(WithKeyring(k), WithInterpolation())
+	m.AddSource(&mockSource{
+		name: "file",
+		data: map[string]Value{
+			"db.password": NewValue(enc),
+			"db.host":     NewValue("h"),
+			"db.url":      NewValue("pg://${db.host}"),
+		},
+	})
+	if err := m.Load(context.Background()); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	p, _ := m.Explain("db.url")
+	if p.Value.String() != "pg://h" || p.Raw.String() != "pg://${db.host}" {
+		t.Errorf("Value = %q, Raw = %q", p.Value.String(), p.Raw.String())
+	}
+
+	p, _ = m.Explain("db.password")
+	if p.Raw.String() != enc || !p.Raw.Sensitive() {
+		t.Errorf("Raw should be the sensitive ciphertext, got %q", p.Raw.String())
+	}
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Redacted 敏感值在输出中的替代文本
+const Redacted = "******"
+
+// DefaultSensitivePatterns 默认的敏感 key 模式
+// 使用 path.Match 语法，匹配时不区分大小写
+var DefaultSensitivePatterns = []string{
+	"password",
+	"*.password",
+	"*passwd*",
+	"*secret*",
+	"*token*",
+	"*api_key*",
+	"*apikey*",
+	"*private_key*",
+	"*credential*",
+}
+
+// isSensitiveKey 检查 key 是否匹配任一敏感模式
+func isSensitiveKey(key string, patterns []string) bool {
+	lower := strings.ToLower(key)
+	for _, p := range patterns {
+		if ok, _ := path.Match(strings.ToLower(p), lower); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// errorValue 返回错误中记录的原始值，敏感值替换为 Redacted
+func errorValue(val Value) any {
+	if val.Sensitive() {
+		return Redacted
+	}
+	return val.Raw()
+}
+
+// markSensitive 将匹配模式或已注册为敏感的 key 标记为敏感值
+// 原始值为嵌套 map 或数组且其中包含敏感子 key 时，整个值都标记为敏感
+func markSensitive(data map[string]Value, patterns []string, registry *Registry) {
+	sensitive := func(key string) bool {
+		if isSensitiveKey(key, patterns) {
+			return true
+		}
+		info, ok := registry.Lookup(key)
+		return ok && info.Sensitive
+	}
+
+	for key, val := range data {
+		if !val.sensitive && containsSensitive(key, val.Raw(), sensitive) {
+			data[key] = val.AsSensitive()
+		}
+	}
+}
+
+// containsSensitive 递归检查 key 及其嵌套子 key 是否敏感
+func containsSensitive(key string, raw any, sensitive func(string) bool) bool {
+	if sensitive(key) {
+		return true
+	}
+	if nested, ok := nestedMap(raw); ok {
+		for k, v := range nested {
+			if containsSensitive(joinKey(key, k), v, sensitive) {
+				return true
+			}
+		}
+	}
+	if list, ok := raw.([]any); ok {
+		for i, v := range list {
+			if containsSensitive(joinKey(key, strconv.Itoa(i)), v, sensitive) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// String 返回按 key 排序的配置转储，敏感值已脱敏
+func (c *configImpl) String() string {
+	keys := c.Keys()
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s=%v\n", k, c.data[k])
+	}
+	return b.String()
+}
@@ -0,0 +1,259 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValue_Format(t *testing.T) {
+	plain := NewValue("hello")
+	secret := NewValue("p@ss").AsSensitive()
+
+	tests := []struct {
+		name   string
+		format string
+		value  Value
+		want   string
+	}{
+		{"plain_v", "%v", plain, "hello"},
+		{"plain_s", "%s", plain, "hello"},
+		{"plain_q", "%q", plain, `"hello"`},
+		{"plain_width", "%-7s|", plain, "hello  |"},
+		{"plain_int", "%d", NewValueFromInterface(42), "42"},
+		{"plain_nil", "%v", NewValueFromInterface(nil), ""},
+		{"sensitive_v", "%v", secret, Redacted},
+		{"sensitive_s", "%s", secret, Redacted},
+		{"sensitive_q", "%q", secret, Redacted},
+		{"sensitive_gostring", "%#v", secret, Redacted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, tt.value); got != tt.want {
+				t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("sensitive_in_map", func(t *testing.T) {
+		got := fmt.Sprintf("%v", map[string]Value{"k": secret})
+		if got != "map[k:"+Redacted+"]" {
+			t.Errorf("Sprintf() = %q", got)
+		}
+	})
+
+	t.Run("string_returns_real_value", func(t *testing.T) {
+		if secret.String() != "p@ss" {
+			t.Errorf("String() = %q, want real value", secret.String())
+		}
+		if !secret.Sensitive() || plain.Sensitive() {
+			t.Error("Sensitive() flag mismatch")
+		}
+	})
+
+	t.Run("error_messages", func(t *testing.T) {
+		_, err := NewValue("p@ss").AsSensitive().IntE()
+		if err == nil || strings.Contains(err.Error(), "p@ss") {
+			t.Errorf("conversion error should not leak sensitive value: %v", err)
+		}
+	})
+}
+
+func TestIsSensitiveKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"password", true},
+		{"database.password", true},
+		{"Database.Password", true},
+		{"github.token", true},
+		{"auth.access_token_ttl", true},
+		{"aws.secret_access_key", true},
+		{"database.host", false},
+		{"server.port", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := isSensitiveKey(tt.key, DefaultSensitivePatterns); got != tt.want {
+				t.Errorf("isSensitiveKey(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkSensitive(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(KeyInfo{Name: "license.key", Sensitive: true})
+
+	data := map[string]Value{
+		"database.host":     NewValue("localhost"),
+		"database.password": NewValue("p@ss"),
+		"license.key":       NewValue("ABC-123"),
+		"custom.pin":        NewValue("0000"),
+		"servers": NewValueFromInterface([]any{
+			map[string]any{"host": "a", "password": "x"},
+		}),
+		"database": NewValueFromInterface(map[string]any{"host": "h", "password": "y"}),
+	}
+
+	markSensitive(data, append(DefaultSensitivePatterns, "*.pin"), registry)
+
+	for _, key := range []string{"database.password", "license.key", "custom.pin", "servers", "database"} {
+		if !data[key].Sensitive() {
+			t.Errorf("%s should be sensitive", key)
+		}
+	}
+	if data["database.host"].Sensitive() {
+		t.Error("database.host should not be sensitive")
+	}
+}
+
+func TestConfigImpl_String(t *testing.T) {
+	cfg := newConfigImplFromMap(map[string]Value{
+		"b.host":     NewValue("localhost"),
+		"a.password": NewValue("p@ss").AsSensitive(),
+	})
+
+	want := "a.password=" + Redacted + "\nb.host=localhost\n"
+	if got := fmt.Sprint(cfg); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestConfigImpl_SensitiveDumps(t *testing.T) {
+	cfg := newConfigImplFromMap(map[string]Value{
+		"database.host":     NewValue("localhost"),
+		"database.password": NewValue("hunter2").AsSensitive(),
+		"database.port":     NewValue("hunter2").AsSensitive(),
+		"api": NewValueFromInterface(map[string]any{
+			"token": "hunter2",
+		}).AsSensitive(),
+	})
+
+	tests := []struct {
+		name string
+		got  func() string
+	}{
+		{"config_string", func() string { return fmt.Sprint(cfg) }},
+		{"sub_string", func() string { return fmt.Sprint(cfg.Sub("api")) }},
+		{"unmarshal_key_error", func() string {
+			var port int64
+			return fmt.Sprint(cfg.UnmarshalKey("database.port", &port))
+		}},
+		{"unmarshal_nested_error", func() string {
+			var target struct {
+				Database struct {
+					Port int `mapstructure:"port"`
+				} `mapstructure:"database"`
+			}
+			return fmt.Sprint(cfg.Unmarshal("", &target))
+		}},
+		{"unmarshal_map_error", func() string {
+			var target struct {
+				Token int `mapstructure:"token"`
+			}
+			return fmt.Sprint(cfg.UnmarshalKey("api", &target))
+		}},
+		{"get_e_error", func() string {
+			_, err := cfg.GetIntE("database.port")
+			return fmt.Sprint(err)
+		}},
+		{"key_validator_error", func() string {
+			key := &Key[string]{name: "database.password", validator: func(string) error { return errors.New("too short") }}
+			_, err := key.Get(cfg)
+			return fmt.Sprintf("%v %#v", err, err)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.got()
+			if strings.Contains(got, "hunter2") {
+				t.Errorf("output leaks sensitive value: %s", got)
+			}
+		})
+	}
+
+	t.Run("sensitive_key_validator_error", func(t *testing.T) {
+		// 未经 Manager 标记的值，由 WithSensitive 声明保证不泄露
+		plain := newConfigImplFromMap(map[string]Value{"license.key": NewValue("hunter2")})
+		key := &Key[string]{name: "license.key", sensitive: true, validator: func(string) error { return errors.New("invalid") }}
+		_, err := key.Get(plain)
+		if got := fmt.Sprintf("%v %#v", err, err); err == nil || strings.Contains(got, "hunter2") {
+			t.Errorf("Get() error = %s, want error without sensitive value", got)
+		}
+	})
+
+	t.Run("values_still_readable", func(t *testing.T) {
+		if got := cfg.GetString("database.password", ""); got != "hunter2" {
+			t.Errorf("GetString() = %q, want real value", got)
+		}
+		database := cfg.AllSettings()["database"].(map[string]any)
+		if database["host"] != "localhost" || database["password"] != "hunter2" {
+			t.Errorf("AllSettings() database = %v, want real values", database)
+		}
+		if got := cfg.GetStringMap("database", nil); got["password"] != "hunter2" {
+			t.Errorf("GetStringMap(database) = %v, want real values", got)
+		}
+		if got := cfg.GetStringMap("api", nil); got["token"] != "hunter2" {
+			t.Errorf("GetStringMap(api) = %v, want real values", got)
+		}
+		var target struct {
+			Token string `mapstructure:"token"`
+		}
+		if err := cfg.UnmarshalKey("api", &target); err != nil || target.Token != "hunter2" {
+			t.Errorf("UnmarshalKey() = %+v, %v, want real value", target, err)
+		}
+	})
+}
+
+func TestManager_SensitiveValues(t *testing.T) {
+	NewKey("test.redact.license", "", "许可证", WithSensitive[string]())
+
+	source := &mockSource{
+		name: "file",
+		data: map[string]Value{
+			"database.password":   NewValue("p@ss"),
+			"database.url":        NewValue("postgres://app:${database.password}@db/app"),
+			"api.pin":             NewValue("1234"),
+			"test.redact.license": NewValue("LIC-1"),
+			"vault.ref":           NewValue("secret://mem/x"),
+			"database.host":       NewValue("localhost"),
+		},
+	}
+
+	m := NewManager(
+		WithInterpolation(),
+		WithSensitiveKeys("*.pin"),
+		WithSecretResolver("mem", SecretResolverFunc(func(ctx context.Context, ref string) (string, error) {
+			return "resolved", nil
+		})),
+	)
+	m.AddSource(source)
+	if err := m.Load(context.Background()); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	cfg := m.Config()
+	dump := fmt.Sprint(cfg)
+	for _, leaked := range []string{"p@ss", "1234", "LIC-1", "resolved"} {
+		if strings.Contains(dump, leaked) {
+			t.Errorf("config dump leaks %q:\n%s", leaked, dump)
+		}
+	}
+	if !strings.Contains(dump, "database.host=localhost") {
+		t.Errorf("config dump should contain non-sensitive values:\n%s", dump)
+	}
+
+	if got := cfg.GetString("database.url", ""); got != "postgres://app:p@ss@db/app" {
+		t.Errorf("GetString() should return real value, got %q", got)
+	}
+	if val, _ := cfg.Get("database.url"); !val.Sensitive() {
+		t.Error("value interpolated from a sensitive value should be sensitive")
+	}
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// KeyLocation 可以提供缺失 key 的配置源位置
+type KeyLocation struct {
+	Source string // 配置源名称
+	Path   string // 配置源中的位置，如环境变量名或 Consul 路径
+}
+
+// MissingKey 缺失的必需配置
+type MissingKey struct {
+	Key       string        // 配置键或前缀
+	Prefix    bool          // 是否为必需前缀
+	Locations []KeyLocation // 可以提供该配置的位置
+}
+
+func (k MissingKey) String() string {
+	s := k.Key
+	if k.Prefix {
+		s += ".*"
+	}
+	if len(k.Locations) > 0 {
+		parts := make([]string, len(k.Locations))
+		for i, loc := range k.Locations {
+			parts[i] = loc.Source + ": " + loc.Path
+		}
+		s += " (set via " + strings.Join(parts, "; ") + ")"
+	}
+	return s
+}
+
+// MissingKeysError 必需配置缺失
+type MissingKeysError struct {
+	Keys []MissingKey
+}
+
+func (e *MissingKeysError) Error() string {
+	lines := make([]string, len(e.Keys))
+	for i, k := range e.Keys {
+		lines[i] = k.String()
+	}
+	return fmt.Sprintf("%v:\n%s", ErrRequiredKeyMissing, strings.Join(lines, "\n"))
+}
+
+// Is 使 errors.Is(err, ErrRequiredKeyMissing) 成立
+func (e *MissingKeysError) Is(target error) bool {
+	return target == ErrRequiredKeyMissing
+}
+
+// checkRequired 检查必需 key 与必需前缀，空字符串值视为缺失
+// 按声明顺序返回所有缺失项，并列出实现 KeyLocator 的配置源中可以提供它的位置
+func checkRequired(data map[string]Value, keys, prefixes []string, sources []Source) error {
+	var missing []MissingKey
+	for _, key := range keys {
+		if !present(data[key]) {
+			missing = append(missing, MissingKey{
+				Key:       key,
+				Locations: locate(sources, key),
+			})
+		}
+	}
+	for _, prefix := range prefixes {
+		if !hasPrefix(data, prefix) {
+			missing = append(missing, MissingKey{
+				Key:       prefix,
+				Prefix:    true,
+				Locations: locate(sources, prefix+".*"),
+			})
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+	return &MissingKeysError{Keys: missing}
+}
+
+// present 判断值是否存在且非空
+func present(v Value) bool {
+	if v.Raw() == nil {
+		return false
+	}
+	s, ok := v.Raw().(string)
+	return !ok || s != ""
+}
+
+// hasPrefix 判断前缀下是否存在非空配置
+func hasPrefix(data map[string]Value, prefix string) bool {
+	for key, v := range data {
+		if (key == prefix || strings.HasPrefix(key, prefix+".")) && present(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// locate 按优先级从高到低列出可以提供 key 的配置源位置
+func locate(sources []Source, key string) []KeyLocation {
+	var locations []KeyLocation
+	for i := len(sources) - 1; i >= 0; i-- {
+		locator, ok := sources[i].(KeyLocator)
+		if !ok {
+			continue
+		}
+		if path := locator.Locate(key); path != "" {
+			locations = append(locations, KeyLocation{Source: sources[i].Name(), Path: path})
+		}
+	}
+	return locations
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// locatorSource 实现 KeyLocator 的测试配置源
+type locatorSource struct {
+	*mockSource
+	locate func(key string) string
+}
+
+func (s *locatorSource) Locate(key string) string {
+	return s.locate(key)
+}
+
+func TestCheckRequired(t *testing.T) {
+	data := map[string]Value{
+		"database.host":     NewValue("localhost"),
+		"database.password": NewValue(""),
+		"cache.ttl":         NewValue("30s"),
+	}
+
+	tests := []struct {
+		name     string
+		keys     []string
+		prefixes []string
+		want     []string
+	}{
+		{"all_present", []string{"database.host"}, []string{"cache"}, nil},
+		{"missing_key", []string{"database.host", "database.user"}, nil, []string{"database.user"}},
+		{"empty_value", []string{"database.password"}, nil, []string{"database.password"}},
+		{"missing_prefix", nil, []string{"cache", "queue"}, []string{"queue.*"}},
+		{"all_reported", []string{"database.user", "database.password"}, []string{"queue"}, []string{"database.user", "database.password", "queue.*"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkRequired(data, tt.keys, tt.prefixes, nil)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("checkRequired() error = %v", err)
+				}
+				return
+			}
+
+			var me *MissingKeysError
+			if !errors.As(err, &me) || !errors.Is(err, ErrRequiredKeyMissing) {
+				t.Fatalf("checkRequired() error = %v, want *MissingKeysError", err)
+			}
+			if len(me.Keys) != len(tt.want) {
+				t.Fatalf("Keys = %v, want %v", me.Keys, tt.want)
+			}
+			for i, want := range tt.want {
+				if got := me.Keys[i].String(); got != want {
+					t.Errorf("Keys[%d] = %q, want %q", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestManager_WithRequiredKeys(t *testing.T) {
+	env := &locatorSource{
+		mockSource: &mockSource{name: "env", priority: 100, data: map[string]Value{}},
+		locate: func(key string) string {
+			return "APP_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+		},
+	}
+	consul := &locatorSource{
+		mockSource: &mockSource{name: "consul", priority: 80, data: map[string]Value{}},
+		locate: func(key string) string {
+			return "config/" + strings.ReplaceAll(key, ".", "/")
+		},
+	}
+	file := &mockSource{
+		name:     "file",
+		priority: 60,
+		data:     map[string]Value{"database.host": NewValue("localhost")},
+	}
+
+	t.Run("lists_locations", func(t *testing.T) {
+		m := NewManager(
+			WithRequiredKeys("database.host", "database.password"),
+			WithRequiredPrefixes("cache"),
+		)
+		m.AddSource(file, consul, env)
+
+		err := m.Load(context.Background())
+		if !errors.Is(err, ErrRequiredKeyMissing) {
+			t.Fatalf("Load() error = %v, want ErrRequiredKeyMissing", err)
+		}
+		for _, want := range []string{
+			"database.password (set via env: APP_DATABASE_PASSWORD; consul: config/database/password)",
+			"cache.* (set via env: APP_CACHE_*; consul: config/cache/*)",
+		} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("error %q should contain %q", err, want)
+			}
+		}
+		if m.Config().Has("database.host") {
+			t.Error("rejected config should not be applied")
+		}
+	})
+
+	t.Run("satisfied", func(t *testing.T) {
+		m := NewManager(WithRequiredKeys("database.host"), WithRequiredPrefixes("database"))
+		m.AddSource(file, env)
+		if err := m.Load(context.Background()); err != nil {
+			t.Errorf("Load() error = %v", err)
+		}
+	})
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// defaultRetryDelay 未设置 InitialDelay 时的首次重试等待时间
+const defaultRetryDelay = 100 * time.Millisecond
+
+// Clock 时钟接口，测试中可注入假时钟避免真实等待
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock 使用系统时间的时钟
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// RetryPolicy 指数退避重试策略
+// 第 n 次重试前等待 InitialDelay * Multiplier^(n-1)，不超过 MaxDelay，并按 Jitter 比例随机抖动
+type RetryPolicy struct {
+	MaxAttempts  int              // 最大尝试次数（含首次），<= 0 表示不限制
+	InitialDelay time.Duration    // 首次重试前的等待时间，0 表示 100ms
+	MaxDelay     time.Duration    // 单次等待上限，0 表示不限制
+	Multiplier   float64          // 等待时间增长倍数，< 1 时为 2
+	Jitter       float64          // 随机抖动比例，等待时间在 d*(1-Jitter) 到 d*(1+Jitter) 之间
+	MaxElapsed   time.Duration    // 从首次尝试起的总时长上限，0 表示不限制
+	Retryable    func(error) bool // 判断错误是否值得重试，nil 表示所有错误都重试
+	Clock        Clock            // 时钟，nil 时使用 SystemClock
+}
+
+// DefaultRetryPolicy 返回默认重试策略：最多 5 次，200ms 起指数增长至 10s，20% 抖动
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:  5,
+		InitialDelay: 200 * time.Millisecond,
+		MaxDelay:     10 * time.Second,
+		Multiplier:   2,
+		Jitter:       0.2,
+	}
+}
+
+// Delay 返回第 attempt 次重试（从 1 开始）前的等待时间，包含随机抖动
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+	return p.delay(attempt, rand.Float64())
+}
+
+// delay 按随机数 r（[0, 1)）计算等待时间
+func (p RetryPolicy) delay(attempt int, r float64) time.Duration {
+	initial := p.InitialDelay
+	if initial <= 0 {
+		initial = defaultRetryDelay
+	}
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	d := float64(initial) * math.Pow(multiplier, float64(max(attempt, 1)-1))
+	if p.MaxDelay > 0 {
+		d = min(d, float64(p.MaxDelay))
+	}
+	if p.Jitter > 0 {
+		d *= 1 + p.Jitter*(2*r-1)
+	}
+	if p.MaxDelay > 0 {
+		d = min(d, float64(p.MaxDelay))
+	}
+	return time.Duration(max(d, 0))
+}
+
+// After 返回第 attempt 次重试等待结束时触发的 channel，便于在 select 中与停止信号一起等待
+func (p RetryPolicy) After(attempt int) <-chan time.Time {
+	return p.clock().After(p.Delay(attempt))
+}
+
+func (p RetryPolicy) clock() Clock {
+	if p.Clock == nil {
+		return SystemClock
+	}
+	return p.Clock
+}
+
+// Do 执行 fn，失败时按策略等待后重试
+// ctx 取消、错误不可重试、达到最大次数，或下一次等待会超过 MaxElapsed 或 ctx 截止时间时返回最后一次错误
+func (p RetryPolicy) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	clock := p.clock()
+	start := clock.Now()
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil || (p.Retryable != nil && !p.Retryable(err)) {
+			return err
+		}
+		if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+			return fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+		}
+
+		delay := p.Delay(attempt)
+		if p.MaxElapsed > 0 && clock.Now().Add(delay).Sub(start) > p.MaxElapsed {
+			return fmt.Errorf("giving up after %d attempts: max elapsed time %s exceeded: %w", attempt, p.MaxElapsed, err)
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return fmt.Errorf("giving up after %d attempts: deadline exceeded: %w", attempt, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-clock.After(delay):
+		}
+	}
+}
+
+// RetrySource 包装配置源，Load 失败时按策略重试
+// 包装后的配置源保持原名称、优先级和 Watch，并转发 KeyLocator
+func RetrySource(source Source, policy RetryPolicy) Source {
+	return &retrySource{Source: source, policy: policy}
+}
+
+type retrySource struct {
+	Source
+	policy RetryPolicy
+}
+
+func (s *retrySource) Load(ctx context.Context) (map[string]Value, error) {
+	var values map[string]Value
+	err := s.policy.Do(ctx, func(ctx context.Context) error {
+		var err error
+		values, err = s.Source.Load(ctx)
+		return err
+	})
+	return values, err
+}
+
+func (s *retrySource) Watch() Watcher {
+	w := s.Source.Watch()
+	if w == nil {
+		return nil
+	}
+	return RetryWatcher(w, s.policy)
+}
+
+// Locate 转发给被包装的配置源，未实现 KeyLocator 时返回空字符串
+func (s *retrySource) Locate(key string) string {
+	if locator, ok := s.Source.(KeyLocator); ok {
+		return locator.Locate(key)
+	}
+	return ""
+}
+
+// RetryWatcher 包装监听器，Start 失败时按策略重试
+func RetryWatcher(w Watcher, policy RetryPolicy) Watcher {
+	return &retryWatcher{Watcher: w, policy: policy}
+}
+
+type retryWatcher struct {
+	Watcher
+	policy RetryPolicy
+}
+
+func (w *retryWatcher) Start(ctx context.Context) (<-chan Event, error) {
+	var ch <-chan Event
+	err := w.policy.Do(ctx, func(ctx context.Context) error {
+		var err error
+		ch, err = w.Watcher.Start(ctx)
+		return err
+	})
+	return ch, err
+}
@@ -0,0 +1,326 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock 记录等待时长并立即推进时间的测试时钟
+type fakeClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	waits []time.Duration
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.waits = append(c.waits, d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func (c *fakeClock) Waits() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]time.Duration(nil), c.waits...)
+}
+
+// flakySource 前 failures 次加载失败的测试配置源
+type flakySource struct {
+	*mockSource
+	mu       sync.Mutex
+	failures int
+	loads    int
+}
+
+func (s *flakySource) Load(ctx context.Context) (map[string]Value, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loads++
+	if s.loads <= s.failures {
+		return nil, errors.New("connection refused")
+	}
+	return s.mockSource.Load(ctx)
+}
+
+// flakyWatcher 前 failures 次启动失败的测试监听器
+type flakyWatcher struct {
+	mockWatcher
+	failures int
+	starts   int
+}
+
+func (w *flakyWatcher) Start(ctx context.Context) (<-chan Event, error) {
+	w.starts++
+	if w.starts <= w.failures {
+		return nil, errors.New("connection refused")
+	}
+	return w.mockWatcher.Start(ctx)
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt int
+		r       float64
+		want    time.Duration
+	}{
+		{"defaults", RetryPolicy{}, 1, 0.5, 100 * time.Millisecond},
+		{"default_multiplier", RetryPolicy{}, 3, 0.5, 400 * time.Millisecond},
+		{"first_attempt", RetryPolicy{InitialDelay: time.Second, Multiplier: 3}, 1, 0.5, time.Second},
+		{"exponential", RetryPolicy{InitialDelay: time.Second, Multiplier: 3}, 3, 0.5, 9 * time.Second},
+		{"max_delay", RetryPolicy{InitialDelay: time.Second, Multiplier: 2, MaxDelay: 5 * time.Second}, 10, 0.5, 5 * time.Second},
+		{"jitter_low", RetryPolicy{InitialDelay: time.Second, Jitter: 0.2}, 1, 0, 800 * time.Millisecond},
+		{"jitter_high", RetryPolicy{InitialDelay: time.Second, Jitter: 0.2}, 1, 1, 1200 * time.Millisecond},
+		{"jitter_capped", RetryPolicy{InitialDelay: time.Second, MaxDelay: time.Second, Jitter: 0.2}, 1, 1, time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.delay(tt.attempt, tt.r); got != tt.want {
+				t.Errorf("delay(%d, %v) = %v, want %v", tt.attempt, tt.r, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("jitter_bounds", func(t *testing.T) {
+		policy := RetryPolicy{InitialDelay: time.Second, Jitter: 0.5}
+		for range 100 {
+			if d := policy.Delay(1); d < 500*time.Millisecond || d > 1500*time.Millisecond {
+				t.Fatalf("Delay(1) = %v, want within 500ms..1.5s", d)
+			}
+		}
+	})
+}
+
+func TestRetryPolicy_Do(t *testing.T) {
+	errFail := errors.New("connection refused")
+	errFatal := errors.New("permission denied")
+
+	tests := []struct {
+		name      string
+		policy    RetryPolicy
+		failures  int
+		err       error
+		wantCalls int
+		wantWaits []time.Duration
+		wantErr   string
+	}{
+		{
+			name:      "success_after_retries",
+			policy:    RetryPolicy{MaxAttempts: 5, InitialDelay: time.Second},
+			failures:  2,
+			err:       errFail,
+			wantCalls: 3,
+			wantWaits: []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			name:      "max_attempts",
+			policy:    RetryPolicy{MaxAttempts: 3, InitialDelay: time.Second},
+			failures:  10,
+			err:       errFail,
+			wantCalls: 3,
+			wantWaits: []time.Duration{time.Second, 2 * time.Second},
+			wantErr:   "giving up after 3 attempts",
+		},
+		{
+			name: "not_retryable",
+			policy: RetryPolicy{
+				MaxAttempts: 5,
+				Retryable:   func(err error) bool { return !errors.Is(err, errFatal) },
+			},
+			failures:  10,
+			err:       errFatal,
+			wantCalls: 1,
+			wantErr:   "permission denied",
+		},
+		{
+			name:      "max_elapsed",
+			policy:    RetryPolicy{InitialDelay: time.Second, MaxElapsed: 5 * time.Second},
+			failures:  10,
+			err:       errFail,
+			wantCalls: 3,
+			wantWaits: []time.Duration{time.Second, 2 * time.Second},
+			wantErr:   "max elapsed time",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock()
+			tt.policy.Clock = clock
+
+			calls := 0
+			err := tt.policy.Do(context.Background(), func(ctx context.Context) error {
+				calls++
+				if calls <= tt.failures {
+					return tt.err
+				}
+				return nil
+			})
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Do() error = %v", err)
+				}
+			} else if !errors.Is(err, tt.err) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Do() error = %v, want %q wrapping %v", err, tt.wantErr, tt.err)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+			if waits := clock.Waits(); !reflect.DeepEqual(waits, tt.wantWaits) {
+				t.Errorf("waits = %v, want %v", waits, tt.wantWaits)
+			}
+		})
+	}
+
+	t.Run("context_canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		policy := RetryPolicy{Clock: newFakeClock()}
+
+		calls := 0
+		err := policy.Do(ctx, func(ctx context.Context) error {
+			calls++
+			if calls == 2 {
+				cancel()
+			}
+			return errFail
+		})
+		if !errors.Is(err, errFail) || calls != 2 {
+			t.Errorf("Do() error = %v, calls = %d, want stop after cancel", err, calls)
+		}
+	})
+
+	t.Run("context_deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		policy := RetryPolicy{InitialDelay: time.Minute, Clock: newFakeClock()}
+
+		calls := 0
+		err := policy.Do(ctx, func(ctx context.Context) error {
+			calls++
+			return errFail
+		})
+		if !errors.Is(err, errFail) || !strings.Contains(err.Error(), "deadline") || calls != 1 {
+			t.Errorf("Do() error = %v, calls = %d, want give up before waiting past deadline", err, calls)
+		}
+	})
+}
+
+func TestRetrySource(t *testing.T) {
+	clock := newFakeClock()
+	policy := RetryPolicy{MaxAttempts: 3, InitialDelay: time.Second, Clock: clock}
+
+	t.Run("load", func(t *testing.T) {
+		source := &flakySource{
+			mockSource: &mockSource{name: "consul", priority: 80, data: map[string]Value{"a": NewValue("1")}},
+			failures:   2,
+		}
+		wrapped := RetrySource(source, policy)
+		if wrapped.Name() != "consul" || wrapped.Priority() != 80 {
+			t.Errorf("wrapped source = %s/%d, want original name and priority", wrapped.Name(), wrapped.Priority())
+		}
+
+		values, err := wrapped.Load(context.Background())
+		if err != nil || values["a"].String() != "1" || source.loads != 3 {
+			t.Errorf("Load() = %v, %v after %d loads, want success on third attempt", values, err, source.loads)
+		}
+	})
+
+	t.Run("watch", func(t *testing.T) {
+		watcher := &flakyWatcher{failures: 1}
+		wrapped := RetrySource(&mockSource{name: "consul", watcher: watcher}, policy)
+
+		ch, err := wrapped.Watch().Start(context.Background())
+		if err != nil || ch == nil || watcher.starts != 2 {
+			t.Errorf("Start() error = %v after %d starts, want success on second attempt", err, watcher.starts)
+		}
+		if err := wrapped.Watch().Stop(); err != nil {
+			t.Errorf("Stop() error = %v", err)
+		}
+	})
+
+	t.Run("no_watcher", func(t *testing.T) {
+		if w := RetrySource(&mockSource{name: "env"}, policy).Watch(); w != nil {
+			t.Errorf("Watch() = %v, want nil", w)
+		}
+	})
+}
+
+func TestManager_WithRetry(t *testing.T) {
+	newSource := func(failures int) *flakySource {
+		return &flakySource{
+			mockSource: &mockSource{name: "consul", data: map[string]Value{"a": NewValue("1")}},
+			failures:   failures,
+		}
+	}
+
+	t.Run("recovers", func(t *testing.T) {
+		clock := newFakeClock()
+		source := newSource(2)
+
+		m := NewManager()
+		m.AddSourceWith(source, WithRetry(RetryPolicy{MaxAttempts: 3, Clock: clock}))
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if got := m.Config().GetString("a", ""); got != "1" {
+			t.Errorf("a = %q, want 1", got)
+		}
+		if len(clock.Waits()) != 2 {
+			t.Errorf("waits = %v, want 2 backoffs", clock.Waits())
+		}
+	})
+
+	t.Run("exhausted_applies_policy", func(t *testing.T) {
+		source := newSource(10)
+
+		m := NewManager()
+		m.AddSourceWith(source,
+			WithPolicy(PolicyOptional),
+			WithRetry(RetryPolicy{MaxAttempts: 3, Clock: newFakeClock()}),
+		)
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		degraded := m.DegradedSources()
+		if len(degraded) != 1 || !strings.Contains(degraded[0].Err.Error(), "giving up after 3 attempts") {
+			t.Errorf("DegradedSources() = %+v, want retries exhausted", degraded)
+		}
+	})
+
+	t.Run("watcher_start", func(t *testing.T) {
+		watcher := &flakyWatcher{failures: 2}
+		source := &mockSource{name: "consul", watcher: watcher}
+
+		m := NewManager()
+		m.AddSourceWith(source, WithRetry(RetryPolicy{MaxAttempts: 3, Clock: newFakeClock()}))
+		if err := m.Watch(); err != nil {
+			t.Fatalf("Watch() error = %v", err)
+		}
+		defer m.Close()
+		if watcher.starts != 3 {
+			t.Errorf("starts = %d, want 3", watcher.starts)
+		}
+	})
+}
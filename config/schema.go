@@ -0,0 +1,629 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Schema 已解析的 JSON Schema
+//
+// 支持常用的校验关键字子集:
+//
+//	type、properties、required、additionalProperties、items、enum、const
+//	minimum、maximum、exclusiveMinimum、exclusiveMaximum、multipleOf
+//	minLength、maxLength、pattern、minItems、maxItems
+//	allOf、anyOf、oneOf、default，以及文档内的 $ref（#/$defs/...、#/definitions/...）
+//
+// 其余关键字被忽略
+type Schema struct {
+	root *schemaNode
+}
+
+// schemaNode 单个 schema 节点
+type schemaNode struct {
+	Ref                  string                 `json:"$ref"`
+	Type                 schemaTypes            `json:"type"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	Required             []string               `json:"required"`
+	AdditionalProperties *schemaOrBool          `json:"additionalProperties"`
+	Items                *schemaNode            `json:"items"`
+	Enum                 []any                  `json:"enum"`
+	Const                json.RawMessage        `json:"const"`
+	Minimum              *float64               `json:"minimum"`
+	Maximum              *float64               `json:"maximum"`
+	ExclusiveMinimum     *float64               `json:"exclusiveMinimum"`
+	ExclusiveMaximum     *float64               `json:"exclusiveMaximum"`
+	MultipleOf           *float64               `json:"multipleOf"`
+	MinLength            *int                   `json:"minLength"`
+	MaxLength            *int                   `json:"maxLength"`
+	Pattern              string                 `json:"pattern"`
+	MinItems             *int                   `json:"minItems"`
+	MaxItems             *int                   `json:"maxItems"`
+	AllOf                []*schemaNode          `json:"allOf"`
+	AnyOf                []*schemaNode          `json:"anyOf"`
+	OneOf                []*schemaNode          `json:"oneOf"`
+	Default              json.RawMessage        `json:"default"`
+	Defs                 map[string]*schemaNode `json:"$defs"`
+	Definitions          map[string]*schemaNode `json:"definitions"`
+
+	ref     *schemaNode    // 解析后的 $ref 目标
+	pattern *regexp.Regexp // 编译后的 pattern
+}
+
+// schemaTypes type 关键字，可以是单个字符串或字符串数组
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+	var multi []string
+	if err := json.Unmarshal(data, &multi); err != nil {
+		return fmt.Errorf("type must be a string or an array of strings")
+	}
+	*t = multi
+	return nil
+}
+
+// schemaOrBool additionalProperties 关键字，可以是布尔值或 schema
+type schemaOrBool struct {
+	allowed bool
+	schema  *schemaNode
+}
+
+func (s *schemaOrBool) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &s.allowed); err == nil {
+		return nil
+	}
+	s.allowed = true
+	return json.Unmarshal(data, &s.schema)
+}
+
+// ParseSchema 解析 JSON Schema 文档
+func ParseSchema(data []byte) (*Schema, error) {
+	var root schemaNode
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+
+	r := &schemaResolver{root: &root, doc: doc, cache: map[string]*schemaNode{"#": &root}}
+	if err := r.prepare(&root, make(map[*schemaNode]bool)); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	return &Schema{root: &root}, nil
+}
+
+// LoadSchemaFile 从文件加载 JSON Schema
+func LoadSchemaFile(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema %s: %w", path, err)
+	}
+	s, err := ParseSchema(data)
+	if err != nil {
+		return nil, fmt.Errorf("schema %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// schemaResolver 解析 $ref 并编译 pattern
+type schemaResolver struct {
+	root  *schemaNode
+	doc   any
+	cache map[string]*schemaNode
+}
+
+func (r *schemaResolver) prepare(n *schemaNode, seen map[*schemaNode]bool) error {
+	if n == nil || seen[n] {
+		return nil
+	}
+	seen[n] = true
+
+	if n.Ref != "" {
+		target, err := r.resolve(n.Ref)
+		if err != nil {
+			return err
+		}
+		n.ref = target
+		if err := r.prepare(target, seen); err != nil {
+			return err
+		}
+	}
+	if n.Pattern != "" {
+		re, err := regexp.Compile(n.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", n.Pattern, err)
+		}
+		n.pattern = re
+	}
+
+	children := []*schemaNode{n.Items}
+	children = append(children, n.AllOf...)
+	children = append(children, n.AnyOf...)
+	children = append(children, n.OneOf...)
+	if n.AdditionalProperties != nil {
+		children = append(children, n.AdditionalProperties.schema)
+	}
+	for _, group := range []map[string]*schemaNode{n.Properties, n.Defs, n.Definitions} {
+		for _, child := range group {
+			children = append(children, child)
+		}
+	}
+	for _, child := range children {
+		if err := r.prepare(child, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolve 解析文档内的 JSON Pointer 引用
+func (r *schemaResolver) resolve(ref string) (*schemaNode, error) {
+	if n, ok := r.cache[ref]; ok {
+		return n, nil
+	}
+	pointer, ok := strings.CutPrefix(ref, "#/")
+	if !ok {
+		return nil, fmt.Errorf("unsupported $ref %q: only local references are supported", ref)
+	}
+
+	node := r.doc
+	for _, token := range strings.Split(pointer, "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch val := node.(type) {
+		case map[string]any:
+			node, ok = val[token]
+		case []any:
+			i, err := strconv.Atoi(token)
+			ok = err == nil && i >= 0 && i < len(val)
+			if ok {
+				node = val[i]
+			}
+		default:
+			ok = false
+		}
+		if !ok {
+			return nil, fmt.Errorf("unresolved $ref %q", ref)
+		}
+	}
+
+	data, err := json.Marshal(node)
+	if err != nil {
+		return nil, err
+	}
+	target := &schemaNode{}
+	if err := json.Unmarshal(data, target); err != nil {
+		return nil, fmt.Errorf("$ref %q: %w", ref, err)
+	}
+	r.cache[ref] = target
+	return target, nil
+}
+
+// deref 返回 $ref 指向的最终节点
+func (n *schemaNode) deref() *schemaNode {
+	for n != nil && n.ref != nil {
+		n = n.ref
+	}
+	return n
+}
+
+// Validate 校验未扁平化的配置文档（如 Config.AllSettings() 的返回值）
+// 返回 *ValidationError，包含全部违规项
+func (s *Schema) Validate(doc any) error {
+	var violations []Violation
+	s.validate(s.root, doc, "", &violations)
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// applySchema 按 schema 转换字符串值的类型并填充默认值，然后校验重建后的配置树
+// 始终返回转换后的配置映射（用于标注配置源）；校验失败时返回的 *ValidationError 尚未标注配置源
+func applySchema(data map[string]Value, s *Schema) (map[string]Value, *ValidationError) {
+	// 配置树中的数组可能与配置源共享，先复制再修改
+	tree := cloneTree(buildTree(data, ""))
+	changes := make(map[string]any)
+	doc := s.apply(s.root, tree, "", changes)
+
+	result := make(map[string]Value, len(data)+len(changes))
+	for k, v := range data {
+		result[k] = v
+	}
+	for k, raw := range changes {
+		val := NewValueFromInterface(raw)
+		if old, ok := data[k]; ok && old.Sensitive() {
+			val = val.AsSensitive()
+		}
+		result[k] = val
+	}
+
+	var violations []Violation
+	s.validate(s.root, doc, "", &violations)
+	if len(violations) > 0 {
+		return result, &ValidationError{Violations: violations}
+	}
+	return result, nil
+}
+
+// apply 递归转换类型并填充默认值，返回处理后的值；发生变化的叶子按扁平 key 记录到 changes
+func (s *Schema) apply(n *schemaNode, v any, path string, changes map[string]any) any {
+	n = n.deref()
+	if n == nil {
+		return v
+	}
+
+	if str, ok := v.(string); ok {
+		if coerced, ok := coerceString(str, n); ok {
+			changes[path] = coerced
+			v = coerced
+		}
+	}
+
+	switch val := v.(type) {
+	case map[string]any:
+		for name, prop := range n.Properties {
+			key := joinKey(path, name)
+			child, exists := val[name]
+			if !exists {
+				def, hasDefault := prop.defaultValue()
+				switch {
+				case hasDefault:
+					child = def
+					recordDefault(key, def, changes)
+				case prop.deref().isObject():
+					// 缺失的对象按空对象处理，以便填充其属性的默认值
+					child = map[string]any{}
+				default:
+					continue
+				}
+			}
+			child = s.apply(prop, child, key, changes)
+			if m, isMap := child.(map[string]any); exists || !isMap || len(m) > 0 {
+				val[name] = child
+			}
+		}
+		if n.AdditionalProperties != nil && n.AdditionalProperties.schema != nil {
+			for name, child := range val {
+				if _, declared := n.Properties[name]; !declared {
+					val[name] = s.apply(n.AdditionalProperties.schema, child, joinKey(path, name), changes)
+				}
+			}
+		}
+	case []any:
+		if n.Items != nil {
+			for i, item := range val {
+				val[i] = s.apply(n.Items, item, joinKey(path, strconv.Itoa(i)), changes)
+			}
+		}
+	}
+	return v
+}
+
+// cloneTree 深拷贝嵌套的 map 和数组
+func cloneTree(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(val))
+		for k, item := range val {
+			m[k] = cloneTree(item)
+		}
+		return m
+	case []any:
+		list := make([]any, len(val))
+		for i, item := range val {
+			list[i] = cloneTree(item)
+		}
+		return list
+	default:
+		if nested, ok := nestedMap(v); ok {
+			return cloneTree(nested)
+		}
+		return v
+	}
+}
+
+// isObject 判断节点是否声明为带属性的对象
+func (n *schemaNode) isObject() bool {
+	return n != nil && len(n.Properties) > 0 && (len(n.Type) == 0 || slices.Contains(n.Type, "object"))
+}
+
+// defaultValue 返回节点的默认值（每次返回新的副本）
+func (n *schemaNode) defaultValue() (any, bool) {
+	n = n.deref()
+	if n == nil || len(n.Default) == 0 {
+		return nil, false
+	}
+	var v any
+	if err := json.Unmarshal(n.Default, &v); err != nil {
+		return nil, false
+	}
+	return normalizeJSON(v), true
+}
+
+// recordDefault 将默认值按扁平 key 记录，对象默认值展开为子 key
+func recordDefault(key string, def any, changes map[string]any) {
+	if m, ok := def.(map[string]any); ok {
+		for k, v := range m {
+			recordDefault(joinKey(key, k), v, changes)
+		}
+		return
+	}
+	changes[key] = def
+}
+
+// normalizeJSON 将 JSON 解码出的整数值 float64 转换为 int
+func normalizeJSON(v any) any {
+	switch val := v.(type) {
+	case float64:
+		if val == math.Trunc(val) && math.Abs(val) < 1<<53 {
+			return int(val)
+		}
+	case map[string]any:
+		for k, item := range val {
+			val[k] = normalizeJSON(item)
+		}
+	case []any:
+		for i, item := range val {
+			val[i] = normalizeJSON(item)
+		}
+	}
+	return v
+}
+
+// coerceString 按 schema 类型转换来自环境变量、Consul 等的字符串值
+// schema 允许 string 类型或无法转换时返回 false
+func coerceString(s string, n *schemaNode) (any, bool) {
+	if len(n.Type) == 0 || slices.Contains(n.Type, "string") {
+		return nil, false
+	}
+	for _, t := range n.Type {
+		switch t {
+		case "integer":
+			if i, err := strconv.ParseInt(strings.TrimSpace(s), 10, 0); err == nil {
+				return int(i), true
+			}
+		case "number":
+			if f, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+				return f, true
+			}
+		case "boolean":
+			if b, err := strconv.ParseBool(strings.TrimSpace(s)); err == nil {
+				return b, true
+			}
+		case "array":
+			var items []any
+			if err := json.Unmarshal([]byte(s), &items); err == nil {
+				return normalizeJSON(items), true
+			}
+			items = []any{}
+			for _, p := range strings.Split(s, ",") {
+				if trimmed := strings.TrimSpace(p); trimmed != "" {
+					items = append(items, trimmed)
+				}
+			}
+			return items, true
+		case "object":
+			var m map[string]any
+			if err := json.Unmarshal([]byte(s), &m); err == nil {
+				return normalizeJSON(m), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// validate 递归校验值，违规项追加到 violations
+func (s *Schema) validate(n *schemaNode, v any, path string, violations *[]Violation) {
+	n = n.deref()
+	if n == nil {
+		return
+	}
+	add := func(rule, format string, args ...any) {
+		*violations = append(*violations, Violation{Key: path, Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(n.Type) > 0 && !slices.ContainsFunc(n.Type, func(t string) bool { return matchesType(v, t) }) {
+		add("type", "must be %s, got %s", strings.Join(n.Type, " or "), jsonTypeOf(v))
+		return
+	}
+
+	if len(n.Enum) > 0 && !slices.ContainsFunc(n.Enum, func(e any) bool { return jsonEqual(e, v) }) {
+		add("enum", "must be one of %s", formatJSON(n.Enum))
+	}
+	if len(n.Const) > 0 {
+		var c any
+		if json.Unmarshal(n.Const, &c) == nil && !jsonEqual(c, v) {
+			add("const", "must be %s", string(n.Const))
+		}
+	}
+
+	if num, ok := jsonNumber(v); ok {
+		got := strconv.FormatFloat(num, 'f', -1, 64)
+		if n.Minimum != nil && num < *n.Minimum {
+			add("minimum", "must be >= %v, got %s", *n.Minimum, got)
+		}
+		if n.Maximum != nil && num > *n.Maximum {
+			add("maximum", "must be <= %v, got %s", *n.Maximum, got)
+		}
+		if n.ExclusiveMinimum != nil && num <= *n.ExclusiveMinimum {
+			add("exclusiveMinimum", "must be > %v, got %s", *n.ExclusiveMinimum, got)
+		}
+		if n.ExclusiveMaximum != nil && num >= *n.ExclusiveMaximum {
+			add("exclusiveMaximum", "must be < %v, got %s", *n.ExclusiveMaximum, got)
+		}
+		if n.MultipleOf != nil && *n.MultipleOf != 0 && math.Mod(num, *n.MultipleOf) != 0 {
+			add("multipleOf", "must be a multiple of %v, got %s", *n.MultipleOf, got)
+		}
+	}
+
+	switch val := v.(type) {
+	case string:
+		length := len([]rune(val))
+		if n.MinLength != nil && length < *n.MinLength {
+			add("minLength", "length must be >= %d, got %d", *n.MinLength, length)
+		}
+		if n.MaxLength != nil && length > *n.MaxLength {
+			add("maxLength", "length must be <= %d, got %d", *n.MaxLength, length)
+		}
+		if n.pattern != nil && !n.pattern.MatchString(val) {
+			add("pattern", "must match pattern %q", n.Pattern)
+		}
+
+	case map[string]any:
+		for _, name := range n.Required {
+			if _, ok := val[name]; !ok {
+				*violations = append(*violations, Violation{Key: joinKey(path, name), Rule: "required", Message: "is required"})
+			}
+		}
+		for _, name := range sortedKeys(val) {
+			key := joinKey(path, name)
+			if prop, ok := n.Properties[name]; ok {
+				s.validate(prop, val[name], key, violations)
+				continue
+			}
+			if ap := n.AdditionalProperties; ap != nil {
+				if !ap.allowed {
+					*violations = append(*violations, Violation{Key: key, Rule: "additionalProperties", Message: "is not allowed"})
+				} else if ap.schema != nil {
+					s.validate(ap.schema, val[name], key, violations)
+				}
+			}
+		}
+
+	case []any:
+		if n.MinItems != nil && len(val) < *n.MinItems {
+			add("minItems", "must have at least %d items, got %d", *n.MinItems, len(val))
+		}
+		if n.MaxItems != nil && len(val) > *n.MaxItems {
+			add("maxItems", "must have at most %d items, got %d", *n.MaxItems, len(val))
+		}
+		if n.Items != nil {
+			for i, item := range val {
+				s.validate(n.Items, item, joinKey(path, strconv.Itoa(i)), violations)
+			}
+		}
+	}
+
+	for _, sub := range n.AllOf {
+		s.validate(sub, v, path, violations)
+	}
+	if len(n.AnyOf) > 0 && s.countMatches(n.AnyOf, v, path) == 0 {
+		add("anyOf", "must match at least one schema in anyOf")
+	}
+	if len(n.OneOf) > 0 {
+		if matched := s.countMatches(n.OneOf, v, path); matched != 1 {
+			add("oneOf", "must match exactly one schema in oneOf, matched %d", matched)
+		}
+	}
+}
+
+// countMatches 返回 v 满足的子 schema 数量
+func (s *Schema) countMatches(nodes []*schemaNode, v any, path string) int {
+	matched := 0
+	for _, sub := range nodes {
+		var violations []Violation
+		s.validate(sub, v, path, &violations)
+		if len(violations) == 0 {
+			matched++
+		}
+	}
+	return matched
+}
+
+// matchesType 判断值是否符合 JSON Schema 类型
+func matchesType(v any, t string) bool {
+	switch t {
+	case "null":
+		return v == nil
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "object":
+		_, ok := v.(map[string]any)
+		return ok
+	case "array":
+		_, ok := v.([]any)
+		return ok
+	case "number":
+		_, ok := jsonNumber(v)
+		return ok
+	case "integer":
+		n, ok := jsonNumber(v)
+		return ok && n == math.Trunc(n)
+	default:
+		return false
+	}
+}
+
+// jsonTypeOf 返回值对应的 JSON 类型名称
+func jsonTypeOf(v any) string {
+	for _, t := range []string{"null", "boolean", "string", "object", "array", "integer", "number"} {
+		if matchesType(v, t) {
+			return t
+		}
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// jsonNumber 将各种数值类型转换为 float64
+func jsonNumber(v any) (float64, bool) {
+	if v == nil {
+		return 0, false
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// jsonEqual 按 JSON 语义比较两个值，数值不区分具体类型
+func jsonEqual(a, b any) bool {
+	if x, ok := jsonNumber(a); ok {
+		y, ok := jsonNumber(b)
+		return ok && x == y
+	}
+	return reflect.DeepEqual(normalizeJSON(a), normalizeJSON(b))
+}
+
+// formatJSON 将值格式化为 JSON 文本
+func formatJSON(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// sortedKeys 返回排序后的 map key
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,288 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSchema = `{
+	"type": "object",
+	"required": ["server"],
+	"properties": {
+		"server": {
+			"type": "object",
+			"required": ["host"],
+			"properties": {
+				"host":    {"type": "string", "minLength": 1},
+				"port":    {"type": "integer", "minimum": 1, "maximum": 65535, "default": 8080},
+				"debug":   {"type": "boolean", "default": false},
+				"ratio":   {"type": "number", "exclusiveMaximum": 1},
+				"mode":    {"enum": ["http", "https"]},
+				"tags":    {"type": "array", "items": {"type": "string"}, "maxItems": 3},
+				"version": {"type": "string", "pattern": "^v[0-9]+$"}
+			}
+		},
+		"database": {"$ref": "#/$defs/database"},
+		"replicas": {"type": "array", "items": {"$ref": "#/$defs/replica"}}
+	},
+	"$defs": {
+		"database": {
+			"type": "object",
+			"properties": {
+				"pool": {
+					"type": "object",
+					"properties": {"size": {"type": "integer", "default": 10}}
+				}
+			}
+		},
+		"replica": {
+			"type": "object",
+			"required": ["host"],
+			"additionalProperties": false,
+			"properties": {
+				"host":   {"type": "string"},
+				"weight": {"type": "integer"}
+			}
+		}
+	}
+}`
+
+func mustParseSchema(t *testing.T, text string) *Schema {
+	t.Helper()
+	s, err := ParseSchema([]byte(text))
+	if err != nil {
+		t.Fatalf("ParseSchema() error = %v", err)
+	}
+	return s
+}
+
+func TestParseSchema_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{"invalid_json", `{`},
+		{"bad_type", `{"type": 1}`},
+		{"bad_pattern", `{"pattern": "("}`},
+		{"unresolved_ref", `{"$ref": "#/$defs/missing"}`},
+		{"remote_ref", `{"$ref": "http://example.com/schema.json"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseSchema([]byte(tt.schema)); err == nil {
+				t.Error("ParseSchema() should fail")
+			}
+		})
+	}
+}
+
+func TestApplySchema(t *testing.T) {
+	s := mustParseSchema(t, testSchema)
+
+	data := map[string]Value{
+		"server.host":         NewValue("localhost"),
+		"server.ratio":        NewValue("0.5"),
+		"server.tags":         NewValue("a,b"),
+		"replicas.0.host":     NewValue("r0"),
+		"replicas.0.weight":   NewValue("3"),
+		"database.pool.extra": NewValue("x"),
+	}
+
+	result, verr := applySchema(data, s)
+	if verr != nil {
+		t.Fatalf("applySchema() error = %v", verr)
+	}
+
+	tests := []struct {
+		key  string
+		want any
+	}{
+		{"server.port", 8080},
+		{"server.debug", false},
+		{"server.ratio", 0.5},
+		{"replicas.0.weight", 3},
+		{"database.pool.size", 10},
+		{"server.host", "localhost"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			val, ok := result[tt.key]
+			if !ok {
+				t.Fatalf("%s missing from result", tt.key)
+			}
+			if val.Raw() != tt.want {
+				t.Errorf("%s = %#v (%T), want %#v", tt.key, val.Raw(), val.Raw(), tt.want)
+			}
+		})
+	}
+
+	t.Run("array_coercion", func(t *testing.T) {
+		list, ok := result["server.tags"].Raw().([]any)
+		if !ok || len(list) != 2 || list[0] != "a" {
+			t.Errorf("server.tags = %#v, want [a b]", result["server.tags"].Raw())
+		}
+	})
+
+	t.Run("input_unchanged", func(t *testing.T) {
+		if _, ok := data["server.port"]; ok || data["server.ratio"].Raw() != "0.5" {
+			t.Error("applySchema() should not modify the input map")
+		}
+	})
+
+	t.Run("sensitive_preserved", func(t *testing.T) {
+		in := map[string]Value{
+			"server.host": NewValue("h"),
+			"server.port": NewValue("443").AsSensitive(),
+		}
+		out, verr := applySchema(in, s)
+		if verr != nil {
+			t.Fatalf("applySchema() error = %v", verr)
+		}
+		if out["server.port"].Raw() != 443 || !out["server.port"].Sensitive() {
+			t.Errorf("server.port = %#v, sensitive = %v", out["server.port"].Raw(), out["server.port"].Sensitive())
+		}
+	})
+}
+
+func TestApplySchema_Violations(t *testing.T) {
+	s := mustParseSchema(t, testSchema)
+
+	tests := []struct {
+		name    string
+		data    map[string]Value
+		wantKey string
+		wantMsg string
+	}{
+		{"required", map[string]Value{"server.port": NewValue("80")}, "server.host", "is required"},
+		{"type", map[string]Value{"server.host": NewValue("h"), "server.port": NewValue("eighty")}, "server.port", "must be integer, got string"},
+		{"maximum", map[string]Value{"server.host": NewValue("h"), "server.port": NewValue("70000")}, "server.port", "must be <= 65535"},
+		{"exclusive_maximum", map[string]Value{"server.host": NewValue("h"), "server.ratio": NewValue("1")}, "server.ratio", "must be < 1"},
+		{"enum", map[string]Value{"server.host": NewValue("h"), "server.mode": NewValue("ftp")}, "server.mode", `must be one of ["http","https"]`},
+		{"min_length", map[string]Value{"server.host": NewValue("")}, "server.host", "length must be >= 1"},
+		{"pattern", map[string]Value{"server.host": NewValue("h"), "server.version": NewValue("1.0")}, "server.version", "must match pattern"},
+		{"max_items", map[string]Value{"server.host": NewValue("h"), "server.tags": NewValue("a,b,c,d")}, "server.tags", "at most 3 items"},
+		{"ref_required", map[string]Value{"server.host": NewValue("h"), "replicas.0.weight": NewValue("1")}, "replicas.0.host", "is required"},
+		{"additional_properties", map[string]Value{"server.host": NewValue("h"), "replicas.0.host": NewValue("r"), "replicas.0.zone": NewValue("z")}, "replicas.0.zone", "is not allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, verr := applySchema(tt.data, s)
+			if verr == nil {
+				t.Fatal("applySchema() should fail")
+			}
+			found := false
+			for _, v := range verr.Violations {
+				if v.Key == tt.wantKey && strings.Contains(v.Message, tt.wantMsg) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("violations %v should contain %s: %s", verr.Violations, tt.wantKey, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestSchema_Combinators(t *testing.T) {
+	s := mustParseSchema(t, `{
+		"properties": {
+			"any":  {"anyOf": [{"type": "integer"}, {"type": "boolean"}]},
+			"one":  {"oneOf": [{"type": "integer"}, {"type": "number"}]},
+			"all":  {"allOf": [{"minimum": 1}, {"maximum": 5}]},
+			"fix":  {"const": "yes"}
+		}
+	}`)
+
+	tests := []struct {
+		name    string
+		doc     map[string]any
+		wantErr bool
+	}{
+		{"valid", map[string]any{"any": true, "one": 1.5, "all": 3, "fix": "yes"}, false},
+		{"any_of", map[string]any{"any": "x"}, true},
+		{"one_of_both", map[string]any{"one": 2}, true},
+		{"all_of", map[string]any{"all": 9}, true},
+		{"const", map[string]any{"fix": "no"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.Validate(tt.doc)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestManager_WithSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.json")
+	if err := os.WriteFile(path, []byte(testSchema), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := LoadSchemaFile(path)
+	if err != nil {
+		t.Fatalf("LoadSchemaFile() error = %v", err)
+	}
+
+	file := &mockSource{
+		name:     "file",
+		priority: 60,
+		data:     map[string]Value{"server.host": NewValue("localhost")},
+	}
+
+	t.Run("coerce_and_default", func(t *testing.T) {
+		env := &mockSource{
+			name:     "env",
+			priority: 100,
+			data:     map[string]Value{"server.debug": NewValue("true")},
+		}
+		m := NewManager(WithSchema(s))
+		m.AddSource(file, env)
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		cfg := m.Config()
+		if raw, _ := cfg.Get("server.debug"); raw.Raw() != true {
+			t.Errorf("server.debug = %#v, want bool true", raw.Raw())
+		}
+		if got := cfg.GetInt("server.port", 0); got != 8080 {
+			t.Errorf("server.port = %d, want default 8080", got)
+		}
+		if got := cfg.GetInt("database.pool.size", 0); got != 10 {
+			t.Errorf("database.pool.size = %d, want default 10", got)
+		}
+	})
+
+	t.Run("violation_names_source", func(t *testing.T) {
+		env := &mockSource{
+			name:     "env",
+			priority: 100,
+			data:     map[string]Value{"server.port": NewValue("99999")},
+		}
+		m := NewManager(WithSchema(s))
+		m.AddSource(file, env)
+
+		err := m.Load(context.Background())
+		if !errors.Is(err, ErrValidationFailed) {
+			t.Fatalf("Load() error = %v, want ErrValidationFailed", err)
+		}
+		if !strings.Contains(err.Error(), "server.port (source: env): must be <= 65535") {
+			t.Errorf("error %q should name key and source", err)
+		}
+	})
+
+	t.Run("load_schema_file_missing", func(t *testing.T) {
+		if _, err := LoadSchemaFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+			t.Error("LoadSchemaFile() should fail for missing file")
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SecretPrefix 密钥引用前缀
+// 形如 secret://<resolver>/<ref> 的配置值会在 Manager.Load 时交给对应的解析器解析
+// 例如: secret://file/run/secrets/db_pass、secret://env/DB_PASSWORD
+const SecretPrefix = "secret://"
+
+// SecretResolver 定义密钥引用解析接口
+// 每个解析器负责一种后端（文件、环境变量、命令输出、Vault 等）
+type SecretResolver interface {
+	// Resolve 解析密钥引用，ref 为 secret://<resolver>/ 之后的部分
+	// 返回的错误中不应包含密钥内容
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// SecretResolverFunc 函数形式的 SecretResolver
+type SecretResolverFunc func(ctx context.Context, ref string) (string, error)
+
+func (f SecretResolverFunc) Resolve(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+// parseSecretRef 解析密钥引用，返回解析器名称和引用内容
+func parseSecretRef(s string) (name, ref string, ok bool) {
+	rest, ok := strings.CutPrefix(s, SecretPrefix)
+	if !ok {
+		return "", "", false
+	}
+	name, ref, _ = strings.Cut(rest, "/")
+	return name, ref, true
+}
+
+// resolveSecrets 解析配置中的所有密钥引用，返回新的配置映射
+// 解析出的值标记为敏感；错误中只包含 key 和引用本身，不包含密钥内容
+func resolveSecrets(ctx context.Context, data map[string]Value, resolvers map[string]SecretResolver) (map[string]Value, error) {
+	var errs []error
+	result := make(map[string]Value, len(data))
+
+	for key, val := range data {
+		s, isString := val.Raw().(string)
+		name, ref, ok := parseSecretRef(s)
+		if !isString || !ok {
+			result[key] = val
+			continue
+		}
+
+		resolver, found := resolvers[name]
+		if !found {
+			errs = append(errs, &KeyError{Key: key, Value: s, Err: fmt.Errorf("%w: no resolver registered for %q", ErrSecretNotResolved, name)})
+			continue
+		}
+
+		secret, err := resolver.Resolve(ctx, ref)
+		if err != nil {
+			errs = append(errs, &KeyError{Key: key, Value: s, Err: fmt.Errorf("%w: %s: %v", ErrSecretNotResolved, name, err)})
+			continue
+		}
+		result[key] = NewValue(secret).AsSensitive()
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return result, nil
+}
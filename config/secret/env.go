@@ -0,0 +1,25 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// Env 从环境变量读取密钥
+// secret://env/DB_PASSWORD 读取环境变量 DB_PASSWORD
+type Env struct{}
+
+// NewEnv 创建环境变量密钥解析器
+func NewEnv() *Env {
+	return &Env{}
+}
+
+// Resolve 读取环境变量，未设置时返回错误
+func (e *Env) Resolve(ctx context.Context, ref string) (string, error) {
+	val, ok := os.LookupEnv(ref)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s not set", ref)
+	}
+	return val, nil
+}
@@ -0,0 +1,73 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultExecTimeout 命令执行默认超时时间
+	DefaultExecTimeout = 10 * time.Second
+)
+
+// Exec 通过执行命令获取密钥，命令的标准输出即为密钥内容
+// 命令在创建时固定，引用只作为最后一个参数传入，配置值无法执行任意命令
+//
+//	secret.NewExec("vault", []string{"kv", "get", "-field=password"})
+//	secret://exec/secret/db 执行 vault kv get -field=password secret/db
+type Exec struct {
+	name    string
+	args    []string
+	timeout time.Duration
+}
+
+// ExecOption 命令解析器选项
+type ExecOption func(*Exec)
+
+// WithTimeout 设置命令执行超时时间
+func WithTimeout(d time.Duration) ExecOption {
+	return func(e *Exec) {
+		e.timeout = d
+	}
+}
+
+// NewExec 创建命令密钥解析器
+func NewExec(name string, args []string, opts ...ExecOption) *Exec {
+	e := &Exec{
+		name:    name,
+		args:    args,
+		timeout: DefaultExecTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// Resolve 执行命令并返回去除末尾换行的标准输出
+// 错误中不包含命令输出，避免泄露密钥
+func (e *Exec) Resolve(ctx context.Context, ref string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	args := append(append([]string{}, e.args...), ref)
+	cmd := exec.CommandContext(ctx, e.name, args...)
+	// 超时后子进程可能仍持有输出管道，限制等待时间
+	cmd.WaitDelay = time.Second
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", fmt.Errorf("command %s failed: %s", e.name, exitErr.ProcessState)
+		}
+		return "", fmt.Errorf("command %s failed: %w", e.name, err)
+	}
+
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
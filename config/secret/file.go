@@ -0,0 +1,56 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// File 从文件读取密钥，适用于 Docker/Kubernetes secrets
+// secret://file/run/secrets/db_pass 读取 <baseDir>/run/secrets/db_pass
+type File struct {
+	baseDir string
+}
+
+// FileOption 文件解析器选项
+type FileOption func(*File)
+
+// WithBaseDir 设置密钥文件根目录（默认为 "/"）
+// 引用路径不允许跳出该目录
+func WithBaseDir(dir string) FileOption {
+	return func(f *File) {
+		f.baseDir = dir
+	}
+}
+
+// NewFile 创建文件密钥解析器
+func NewFile(opts ...FileOption) *File {
+	f := &File{baseDir: "/"}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
+
+// Resolve 读取文件内容，去除末尾换行
+func (f *File) Resolve(ctx context.Context, ref string) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	default:
+	}
+
+	// 先按绝对路径清理，确保 ".." 无法跳出 baseDir
+	path := filepath.Join(f.baseDir, filepath.Clean("/"+ref))
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
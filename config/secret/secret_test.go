@@ -0,0 +1,103 @@
+package secret
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFile_Resolve(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "db_pass"), []byte("s3cret\n"), 0600); err != nil {
+		t.Fatalf("Failed to write secret file: %v", err)
+	}
+
+	t.Run("base_dir", func(t *testing.T) {
+		f := NewFile(WithBaseDir(tmpDir))
+		got, err := f.Resolve(context.Background(), "db_pass")
+		if err != nil {
+			t.Fatalf("Resolve() error = %v", err)
+		}
+		if got != "s3cret" {
+			t.Errorf("Resolve() = %q, want trailing newline trimmed", got)
+		}
+	})
+
+	t.Run("absolute_path", func(t *testing.T) {
+		f := NewFile()
+		got, err := f.Resolve(context.Background(), strings.TrimPrefix(filepath.Join(tmpDir, "db_pass"), "/"))
+		if err != nil || got != "s3cret" {
+			t.Errorf("Resolve() = %q, %v", got, err)
+		}
+	})
+
+	t.Run("escape_base_dir", func(t *testing.T) {
+		sub := filepath.Join(tmpDir, "sub")
+		if err := os.Mkdir(sub, 0700); err != nil {
+			t.Fatal(err)
+		}
+		f := NewFile(WithBaseDir(sub))
+		if _, err := f.Resolve(context.Background(), "../db_pass"); err == nil {
+			t.Error("Resolve() should not read files outside base dir")
+		}
+	})
+
+	t.Run("missing_file", func(t *testing.T) {
+		f := NewFile(WithBaseDir(tmpDir))
+		if _, err := f.Resolve(context.Background(), "missing"); err == nil {
+			t.Error("Resolve() should fail for missing file")
+		}
+	})
+}
+
+func TestEnv_Resolve(t *testing.T) {
+	t.Setenv("SECRET_TEST_TOKEN", "tok")
+
+	e := NewEnv()
+	got, err := e.Resolve(context.Background(), "SECRET_TEST_TOKEN")
+	if err != nil || got != "tok" {
+		t.Errorf("Resolve() = %q, %v", got, err)
+	}
+
+	if _, err := e.Resolve(context.Background(), "SECRET_TEST_MISSING"); err == nil {
+		t.Error("Resolve() should fail for unset variable")
+	}
+}
+
+func TestExec_Resolve(t *testing.T) {
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		t.Skip("/bin/sh not available")
+	}
+
+	t.Run("stdout", func(t *testing.T) {
+		e := NewExec("/bin/sh", []string{"-c", `echo "value-for-$0"`})
+		got, err := e.Resolve(context.Background(), "db")
+		if err != nil {
+			t.Fatalf("Resolve() error = %v", err)
+		}
+		if got != "value-for-db" {
+			t.Errorf("Resolve() = %q", got)
+		}
+	})
+
+	t.Run("failure_hides_output", func(t *testing.T) {
+		e := NewExec("/bin/sh", []string{"-c", "echo leaked; exit 3"})
+		_, err := e.Resolve(context.Background(), "db")
+		if err == nil {
+			t.Fatal("Resolve() should fail on non-zero exit")
+		}
+		if strings.Contains(err.Error(), "leaked") {
+			t.Errorf("error %q should not contain command output", err)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		e := NewExec("/bin/sh", []string{"-c", "exec sleep 5"}, WithTimeout(50*time.Millisecond))
+		if _, err := e.Resolve(context.Background(), "db"); err == nil {
+			t.Error("Resolve() should fail on timeout")
+		}
+	})
+}
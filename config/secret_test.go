@@ -0,0 +1,119 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestResolveSecrets(t *testing.T) {
+	resolvers := map[string]SecretResolver{
+		"mem": SecretResolverFunc(func(ctx context.Context, ref string) (string, error) {
+			if ref == "db/password" {
+				return "p@ss", nil
+			}
+			return "", errors.New("not found")
+		}),
+	}
+
+	t.Run("resolve", func(t *testing.T) {
+		data := map[string]Value{
+			"database.password": NewValue("secret://mem/db/password"),
+			"database.host":     NewValue("localhost"),
+			"database.port":     NewValueFromInterface(5432),
+		}
+		result, err := resolveSecrets(context.Background(), data, resolvers)
+		if err != nil {
+			t.Fatalf("resolveSecrets() error = %v", err)
+		}
+		if got := result["database.password"].String(); got != "p@ss" {
+			t.Errorf("database.password = %q", got)
+		}
+		if result["database.host"].String() != "localhost" || result["database.port"].Int(0) != 5432 {
+			t.Error("non-secret values should be kept as is")
+		}
+		if data["database.password"].String() != "secret://mem/db/password" {
+			t.Error("resolveSecrets() should not modify the input map")
+		}
+	})
+
+	t.Run("unknown_resolver", func(t *testing.T) {
+		_, err := resolveSecrets(context.Background(), map[string]Value{
+			"token": NewValue("secret://vault/token"),
+		}, resolvers)
+		if !errors.Is(err, ErrSecretNotResolved) {
+			t.Fatalf("resolveSecrets() error = %v, want ErrSecretNotResolved", err)
+		}
+		if !strings.Contains(err.Error(), "token") || !strings.Contains(err.Error(), "vault") {
+			t.Errorf("error %q should mention key and resolver", err)
+		}
+	})
+
+	t.Run("resolver_error", func(t *testing.T) {
+		_, err := resolveSecrets(context.Background(), map[string]Value{
+			"token": NewValue("secret://mem/missing"),
+		}, resolvers)
+		if !errors.Is(err, ErrSecretNotResolved) {
+			t.Errorf("resolveSecrets() error = %v, want ErrSecretNotResolved", err)
+		}
+	})
+}
+
+func TestManager_WithSecretResolver(t *testing.T) {
+	source := &mockSource{
+		name:     "file",
+		priority: 60,
+		data: map[string]Value{
+			"database.password": NewValue("secret://mem/db"),
+			"database.url":      NewValue("postgres://app:${database.password}@db/app"),
+		},
+	}
+	resolver := SecretResolverFunc(func(ctx context.Context, ref string) (string, error) {
+		return "s3cret", nil
+	})
+
+	t.Run("resolve_before_interpolation", func(t *testing.T) {
+		m := NewManager(WithSecretResolver("mem", resolver), WithInterpolation())
+		m.AddSource(source)
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		cfg := m.Config()
+		if got := cfg.GetString("database.password", ""); got != "s3cret" {
+			t.Errorf("database.password = %q", got)
+		}
+		if got := cfg.GetString("database.url", ""); got != "postgres://app:s3cret@db/app" {
+			t.Errorf("database.url = %q", got)
+		}
+	})
+
+	t.Run("secret_not_interpolated", func(t *testing.T) {
+		resolver := SecretResolverFunc(func(ctx context.Context, ref string) (string, error) {
+			return "pa${ss", nil
+		})
+		m := NewManager(WithSecretResolver("mem", resolver), WithInterpolation())
+		m.AddSource(source)
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		cfg := m.Config()
+		if got := cfg.GetString("database.password", ""); got != "pa${ss" {
+			t.Errorf("database.password = %q, want secret kept verbatim", got)
+		}
+		if got := cfg.GetString("database.url", ""); got != "postgres://app:pa${ss@db/app" {
+			t.Errorf("database.url = %q", got)
+		}
+	})
+
+	t.Run("no_resolvers", func(t *testing.T) {
+		m := NewManager()
+		m.AddSource(source)
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if got := m.Config().GetString("database.password", ""); got != "secret://mem/db" {
+			t.Errorf("database.password = %q, want reference kept as is", got)
+		}
+	})
+}
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// snapshotVersion 快照文件格式版本
+const snapshotVersion = 1
+
+// SnapshotCache 将配置源最近一次成功加载的结果保存到本地目录
+// 远程配置源启动时不可达，可从快照恢复，避免服务因配置中心故障反复重启
+type SnapshotCache struct {
+	dir     string
+	keyring *Keyring
+}
+
+// NewSnapshotCache 创建快照缓存，每个配置源对应 dir 下的一个文件
+// keyring 不为 nil 时快照内容使用其主密钥加密
+func NewSnapshotCache(dir string, keyring *Keyring) *SnapshotCache {
+	return &SnapshotCache{dir: dir, keyring: keyring}
+}
+
+// snapshotFile 快照文件内容
+type snapshotFile struct {
+	Version  int       `json:"version"`
+	Source   string    `json:"source"`
+	SavedAt  time.Time `json:"saved_at"`
+	Checksum string    `json:"checksum"` // payload 的 SHA-256
+	Payload  string    `json:"payload"`  // JSON 编码的配置，加密时为 enc:v1:... 密文
+}
+
+// Save 原子地写入配置源的快照
+// 先写入同目录的临时文件并同步到磁盘，再重命名覆盖，读取方不会看到写了一半的文件
+func (c *SnapshotCache) Save(source string, values map[string]Value) error {
+	raw := make(map[string]any, len(values))
+	for k, v := range values {
+		raw[k] = v.Raw()
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot of %s: %w", source, err)
+	}
+
+	payload := string(data)
+	if c.keyring != nil {
+		if payload, err = c.keyring.Encrypt(payload); err != nil {
+			return fmt.Errorf("failed to encrypt snapshot of %s: %w", source, err)
+		}
+	}
+
+	content, err := json.MarshalIndent(snapshotFile{
+		Version:  snapshotVersion,
+		Source:   source,
+		SavedAt:  time.Now().UTC(),
+		Checksum: checksum(payload),
+		Payload:  payload,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot of %s: %w", source, err)
+	}
+
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create snapshot dir: %w", err)
+	}
+	return writeFileAtomic(c.path(source), content)
+}
+
+// Load 读取配置源的快照，返回配置和保存时间
+// 快照不存在时返回的错误满足 errors.Is(err, os.ErrNotExist)，校验和不匹配或格式错误时返回 ErrSnapshotCorrupt
+func (c *SnapshotCache) Load(source string) (map[string]Value, time.Time, error) {
+	content, err := os.ReadFile(c.path(source))
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to read snapshot of %s: %w", source, err)
+	}
+
+	var file snapshotFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, time.Time{}, fmt.Errorf("%w: %s: %v", ErrSnapshotCorrupt, source, err)
+	}
+	if file.Version != snapshotVersion || file.Source != source {
+		return nil, time.Time{}, fmt.Errorf("%w: %s: unexpected version %d or source %q", ErrSnapshotCorrupt, source, file.Version, file.Source)
+	}
+	if checksum(file.Payload) != file.Checksum {
+		return nil, time.Time{}, fmt.Errorf("%w: %s: checksum mismatch", ErrSnapshotCorrupt, source)
+	}
+
+	payload := file.Payload
+	if strings.HasPrefix(payload, EncryptedPrefix) {
+		if c.keyring == nil {
+			return nil, time.Time{}, fmt.Errorf("%w: snapshot of %s is encrypted but no keyring is configured", ErrDecryptFailed, source)
+		}
+		if payload, err = c.keyring.Decrypt(payload); err != nil {
+			return nil, time.Time{}, fmt.Errorf("snapshot of %s: %w", source, err)
+		}
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal([]byte(payload), &raw); err != nil {
+		return nil, time.Time{}, fmt.Errorf("%w: %s: %v", ErrSnapshotCorrupt, source, err)
+	}
+
+	values := make(map[string]Value, len(raw))
+	for k, v := range raw {
+		values[k] = NewValueFromInterface(normalizeJSON(v))
+	}
+	return values, file.SavedAt, nil
+}
+
+// path 返回配置源的快照文件路径
+// 配置源名称可能包含 : / 等字符，替换后附加名称哈希避免冲突
+func (c *SnapshotCache) path(source string) string {
+	safe := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '.' {
+			return r
+		}
+		return '_'
+	}, source)
+	return filepath.Join(c.dir, safe+"-"+checksum(source)[:8]+".json")
+}
+
+// checksum 计算 SHA-256 十六进制摘要
+func checksum(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// writeFileAtomic 通过临时文件和重命名原子地写入文件
+func writeFileAtomic(path string, content []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".snapshot-*")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace snapshot: %w", err)
+	}
+	return nil
+}
+
+// restoreSnapshot 内存中没有上次结果时，从快照恢复 PolicyFallback 配置源的配置
+// 恢复成功时标记 status 为过期，LoadedAt 为快照保存时间
+func restoreSnapshot(status *SourceStatus, opts sourceOptions, name string, last map[string]Value, hasLast bool) (map[string]Value, bool) {
+	status.Stale = false
+	if hasLast || opts.snapshot == nil || status.Policy != PolicyFallback {
+		return last, hasLast
+	}
+
+	values, savedAt, err := opts.snapshot.Load(name)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			status.SnapshotErr = err
+		}
+		return last, hasLast
+	}
+	status.Stale = true
+	status.LoadedAt = savedAt
+	return values, true
+}
+
+// saveSnapshots 为本次成功加载且内容有变化的配置源写入快照，写入失败记录在 status 中
+// 上次写入失败（SnapshotErr 不为空）时即使内容没有变化也重新写入，直到写入成功才清除 SnapshotErr
+func saveSnapshots(sources []Source, opts []sourceOptions, loaded []bool, previous, current []map[string]Value, statuses []SourceStatus) {
+	for i, source := range sources {
+		if !loaded[i] || opts[i].snapshot == nil {
+			continue
+		}
+		if previous[i] != nil && statuses[i].SnapshotErr == nil && diff(previous[i], current[i]).Empty() {
+			continue
+		}
+		statuses[i].SnapshotErr = opts[i].snapshot.Save(source.Name(), current[i])
+	}
+}
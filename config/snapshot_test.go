@@ -0,0 +1,229 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSnapshotCache_SaveLoad(t *testing.T) {
+	values := map[string]Value{
+		"database.host": NewValue("db"),
+		"database.port": NewValueFromInterface(5432),
+		"feature.on":    NewValueFromInterface(true),
+		"servers":       NewValueFromInterface([]any{"a", "b"}),
+	}
+
+	tests := []struct {
+		name    string
+		keyring *Keyring
+	}{
+		{"plain", nil},
+		{"encrypted", newTestKeyring(t, "k1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			cache := NewSnapshotCache(dir, tt.keyring)
+			if err := cache.Save("consul:localhost:8500/config", values); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+
+			got, savedAt, err := cache.Load("consul:localhost:8500/config")
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if savedAt.IsZero() {
+				t.Error("saved time should be recorded")
+			}
+			for key, want := range values {
+				if !reflect.DeepEqual(got[key].Raw(), want.Raw()) {
+					t.Errorf("%s = %#v, want %#v", key, got[key].Raw(), want.Raw())
+				}
+			}
+
+			entries, _ := os.ReadDir(dir)
+			if len(entries) != 1 || strings.HasPrefix(entries[0].Name(), ".snapshot-") {
+				t.Errorf("snapshot dir = %v, want a single snapshot file", entries)
+			}
+			content, _ := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+			if encrypted := !strings.Contains(string(content), "database.host"); encrypted != (tt.keyring != nil) {
+				t.Errorf("snapshot content encrypted = %v, want %v", encrypted, tt.keyring != nil)
+			}
+		})
+	}
+}
+
+func TestSnapshotCache_LoadErrors(t *testing.T) {
+	values := map[string]Value{"a": NewValue("1")}
+
+	tests := []struct {
+		name    string
+		prepare func(t *testing.T, dir string)
+		keyring *Keyring
+		wantErr error
+	}{
+		{
+			name:    "missing",
+			prepare: func(t *testing.T, dir string) {},
+			wantErr: os.ErrNotExist,
+		},
+		{
+			name: "checksum_mismatch",
+			prepare: func(t *testing.T, dir string) {
+				cache := NewSnapshotCache(dir, nil)
+				if err := cache.Save("consul", values); err != nil {
+					t.Fatal(err)
+				}
+				path := cache.path("consul")
+				content, _ := os.ReadFile(path)
+				tampered := strings.Replace(string(content), `\"a\":\"1\"`, `\"a\":\"2\"`, 1)
+				if err := os.WriteFile(path, []byte(tampered), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr: ErrSnapshotCorrupt,
+		},
+		{
+			name: "truncated",
+			prepare: func(t *testing.T, dir string) {
+				cache := NewSnapshotCache(dir, nil)
+				if err := os.WriteFile(cache.path("consul"), []byte(`{"version":1,`), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr: ErrSnapshotCorrupt,
+		},
+		{
+			name: "encrypted_without_keyring",
+			prepare: func(t *testing.T, dir string) {
+				if err := NewSnapshotCache(dir, newTestKeyring(t, "k1")).Save("consul", values); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr: ErrDecryptFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			tt.prepare(t, dir)
+			if _, _, err := NewSnapshotCache(dir, tt.keyring).Load("consul"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Load() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestManager_WithSnapshot(t *testing.T) {
+	cache := NewSnapshotCache(t.TempDir(), nil)
+	file := &mockSource{name: "file", priority: 60, data: map[string]Value{"a": NewValue("file")}}
+	newConsul := func() *mockSource {
+		return &mockSource{name: "consul", priority: 80, data: map[string]Value{"database.host": NewValue("db")}}
+	}
+
+	// 首次启动成功，写入快照
+	consul := newConsul()
+	m := NewManager()
+	m.AddSource(file).AddSourceWith(consul, WithPolicy(PolicyFallback), WithSnapshot(cache))
+	if err := m.Load(context.Background()); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if _, _, err := cache.Load("consul"); err != nil {
+		t.Fatalf("snapshot should be saved: %v", err)
+	}
+
+	t.Run("restore_at_startup", func(t *testing.T) {
+		consul := newConsul()
+		consul.loadErr = errors.New("connection refused")
+
+		m := NewManager()
+		m.AddSource(file).AddSourceWith(consul, WithPolicy(PolicyFallback), WithSnapshot(cache))
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if got := m.Config().GetString("database.host", ""); got != "db" {
+			t.Errorf("database.host = %q, want value from snapshot", got)
+		}
+		degraded := m.DegradedSources()
+		if len(degraded) != 1 || !degraded[0].Stale || !degraded[0].Fallback || degraded[0].LoadedAt.IsZero() {
+			t.Fatalf("DegradedSources() = %+v, want stale consul", degraded)
+		}
+
+		// 配置源恢复后切回实时数据
+		consul.loadErr = nil
+		consul.data = map[string]Value{"database.host": NewValue("db2")}
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if got := m.Config().GetString("database.host", ""); got != "db2" || len(m.DegradedSources()) != 0 {
+			t.Errorf("database.host = %q, degraded = %+v, want live value", got, m.DegradedSources())
+		}
+		if values, _, _ := cache.Load("consul"); values["database.host"].String() != "db2" {
+			t.Errorf("snapshot should be updated after recovery, got %v", values)
+		}
+	})
+
+	t.Run("required_ignores_snapshot", func(t *testing.T) {
+		consul := newConsul()
+		consul.loadErr = errors.New("connection refused")
+
+		m := NewManager()
+		m.AddSource(file).AddSourceWith(consul, WithSnapshot(cache))
+		if err := m.Load(context.Background()); err == nil {
+			t.Error("Load() should fail for required source")
+		}
+	})
+
+	t.Run("retry_failed_save", func(t *testing.T) {
+		// 快照目录的上级路径是普通文件，写入失败
+		blocker := filepath.Join(t.TempDir(), "blocker")
+		if err := os.WriteFile(blocker, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		cache := NewSnapshotCache(filepath.Join(blocker, "snapshots"), nil)
+
+		m := NewManager()
+		m.AddSourceWith(newConsul(), WithPolicy(PolicyFallback), WithSnapshot(cache))
+		for range 2 {
+			if err := m.Load(context.Background()); err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if status := m.SourceStatus(); status[0].SnapshotErr == nil {
+				t.Fatalf("SourceStatus() = %+v, want SnapshotErr while disk is broken", status)
+			}
+		}
+
+		// 磁盘恢复后，内容未变化也会重新写入
+		if err := os.Remove(blocker); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if status := m.SourceStatus(); status[0].SnapshotErr != nil {
+			t.Errorf("SnapshotErr = %v, want nil after successful save", status[0].SnapshotErr)
+		}
+		if _, _, err := cache.Load("consul"); err != nil {
+			t.Errorf("snapshot should be saved after disk recovers: %v", err)
+		}
+	})
+
+	t.Run("rejected_config_not_saved", func(t *testing.T) {
+		cache := NewSnapshotCache(t.TempDir(), nil)
+		m := NewManager(WithRequiredKeys("missing"))
+		m.AddSourceWith(newConsul(), WithPolicy(PolicyFallback), WithSnapshot(cache))
+		if err := m.Load(context.Background()); err == nil {
+			t.Fatal("Load() should fail")
+		}
+		if _, _, err := cache.Load("consul"); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("snapshot should not be written for rejected config, err = %v", err)
+		}
+	})
+}
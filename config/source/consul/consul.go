@@ -24,12 +24,22 @@ type Source struct {
 	priority  int
 	separator string
 
+	connectRetry *config.RetryPolicy // 创建时连接检查的重试策略
+	lazy         bool                // 创建时不检查连接，由 Load 报告不可达
+	retry        config.RetryPolicy  // 监听出错后的退避策略
+
 	mu      sync.RWMutex
 	watcher *watcher
 }
 
 // New 创建 Consul KV 配置源
+// 设置了不限次数和总时长的重试策略时会一直等到连接成功，需要取消时使用 NewWithContext
 func New(address string, opts ...Option) (*Source, error) {
+	return NewWithContext(context.Background(), address, opts...)
+}
+
+// NewWithContext 创建 Consul KV 配置源，ctx 用于取消创建时的连接检查及其重试等待
+func NewWithContext(ctx context.Context, address string, opts ...Option) (*Source, error) {
 	cfg := consulapi.DefaultConfig()
 	cfg.Address = address
 
@@ -38,24 +48,37 @@ func New(address string, opts ...Option) (*Source, error) {
 		return nil, fmt.Errorf("failed to create consul client: %w", err)
 	}
 
-	// 验证连接
-	_, err = client.Status().Leader()
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to consul at %s: %w", address, err)
-	}
-
 	s := &Source{
 		client:    client,
 		addr:      address,
 		prefix:    DefaultPrefix,
 		priority:  DefaultPriority,
 		separator: "/",
+		retry:     defaultWatchRetry,
 	}
 
 	for _, opt := range opts {
 		opt(s)
 	}
 
+	if s.lazy {
+		return s, nil
+	}
+
+	// 验证连接，设置了 WithRetry 时按策略重试
+	connect := func(ctx context.Context) error {
+		_, err := s.client.Status().LeaderWithQueryOptions((&consulapi.QueryOptions{}).WithContext(ctx))
+		return err
+	}
+	if s.connectRetry != nil {
+		err = s.connectRetry.Do(ctx, connect)
+	} else {
+		err = connect(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to consul at %s: %w", address, err)
+	}
+
 	return s, nil
 }
 
@@ -98,13 +121,18 @@ func (s *Source) Load(ctx context.Context) (map[string]config.Value, error) {
 	return result, nil
 }
 
+// Locate 返回提供 key 的 Consul KV 路径，如 database.password -> config/database/password
+func (s *Source) Locate(key string) string {
+	return s.prefix + "/" + strings.ReplaceAll(key, ".", "/")
+}
+
 // Watch 返回 Consul 监听器
 func (s *Source) Watch() config.Watcher {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	if s.watcher == nil {
-		s.watcher = newWatcher(s.client, s.prefix)
+		s.watcher = newWatcher(s.client, s.prefix, s.retry)
 	}
 	return s.watcher
 }
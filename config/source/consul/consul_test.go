@@ -2,8 +2,11 @@ package consul
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/CloudRoamer/aimo-libs/config"
 )
 
 // TestNew 测试创建 Consul 配置源
@@ -164,3 +167,61 @@ func TestWithPrefix(t *testing.T) {
 		})
 	}
 }
+
+// TestSource_Locate 测试 key 对应的 KV 路径
+func TestSource_Locate(t *testing.T) {
+	s := &Source{prefix: "config/prod"}
+	want := "config/prod/database/password"
+	if got := s.Locate("database.password"); got != want {
+		t.Errorf("Locate() = %v, want %v", got, want)
+	}
+}
+
+// TestWithRetry 测试连接重试选项
+func TestWithRetry(t *testing.T) {
+	policy := config.RetryPolicy{MaxAttempts: 2, InitialDelay: time.Millisecond}
+	_, err := New("127.0.0.1:1", WithRetry(policy))
+	if err == nil || !strings.Contains(err.Error(), "giving up after 2 attempts") {
+		t.Errorf("New() error = %v, want retries exhausted", err)
+	}
+}
+
+// TestWithLazyConnect 测试 Consul 不可达时从快照启动
+func TestWithLazyConnect(t *testing.T) {
+	s, err := New("127.0.0.1:1", WithLazyConnect(), WithPrefix("config/app"))
+	if err != nil {
+		t.Fatalf("New() error = %v, want lazy connect", err)
+	}
+
+	snapshots := config.NewSnapshotCache(t.TempDir(), nil)
+	if err := snapshots.Save(s.Name(), map[string]config.Value{"database.host": config.NewValue("db")}); err != nil {
+		t.Fatal(err)
+	}
+
+	mgr := config.NewManager()
+	mgr.AddSourceWith(s, config.WithPolicy(config.PolicyFallback), config.WithSnapshot(snapshots))
+	if err := mgr.Load(context.Background()); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := mgr.Config().GetString("database.host", ""); got != "db" {
+		t.Errorf("database.host = %q, want value from snapshot", got)
+	}
+	if degraded := mgr.DegradedSources(); len(degraded) != 1 || !degraded[0].Stale {
+		t.Errorf("DegradedSources() = %+v, want stale consul", degraded)
+	}
+}
+
+// TestNewWithContext 测试取消不限次数的连接重试
+func TestNewWithContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := NewWithContext(ctx, "127.0.0.1:1", WithRetry(config.RetryPolicy{InitialDelay: time.Millisecond}))
+	if err == nil {
+		t.Fatal("NewWithContext() should fail")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("NewWithContext() took %v, want to stop when ctx is done", elapsed)
+	}
+}
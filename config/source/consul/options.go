@@ -4,6 +4,8 @@ import (
 	"strings"
 
 	consulapi "github.com/hashicorp/consul/api"
+
+	"github.com/CloudRoamer/aimo-libs/config"
 )
 
 // Option Consul 配置源选项
@@ -50,3 +52,21 @@ func WithSeparator(sep string) Option {
 		s.separator = sep
 	}
 }
+
+// WithLazyConnect 创建时不检查 Consul 是否可达，连接失败由 Load 返回
+// 配合 config.PolicyFallback 和 config.WithSnapshot 使用，Consul 不可达时服务仍可从快照启动
+func WithLazyConnect() Option {
+	return func(s *Source) {
+		s.lazy = true
+	}
+}
+
+// WithRetry 设置重试策略
+// New 连接检查失败时按策略重试，监听出错后按策略的退避时间等待（忽略 MaxAttempts，持续重试）
+// 策略不限制 MaxAttempts 和 MaxElapsed 时，应使用 NewWithContext 以便取消创建
+func WithRetry(policy config.RetryPolicy) Option {
+	return func(s *Source) {
+		s.connectRetry = &policy
+		s.retry = policy
+	}
+}
@@ -13,22 +13,30 @@ import (
 const (
 	// Consul blocking query 默认等待时间
 	defaultWaitTime = 5 * time.Minute
-	// 错误后重试等待时间
-	retryWaitTime = 5 * time.Second
 )
 
+// defaultWatchRetry 监听出错后的默认退避策略：1s 起指数增长至 1 分钟，持续重试
+var defaultWatchRetry = config.RetryPolicy{
+	InitialDelay: time.Second,
+	MaxDelay:     time.Minute,
+	Multiplier:   2,
+	Jitter:       0.2,
+}
+
 type watcher struct {
 	client    *consulapi.Client
 	prefix    string
+	retry     config.RetryPolicy
 	lastIndex uint64
 	stopCh    chan struct{}
 	eventCh   chan config.Event
 }
 
-func newWatcher(client *consulapi.Client, prefix string) *watcher {
+func newWatcher(client *consulapi.Client, prefix string, retry config.RetryPolicy) *watcher {
 	return &watcher{
 		client: client,
 		prefix: prefix,
+		retry:  retry,
 		stopCh: make(chan struct{}),
 	}
 }
@@ -45,6 +53,7 @@ func (w *watcher) watch(ctx context.Context) {
 	defer close(w.eventCh)
 
 	kv := w.client.KV()
+	failures := 0
 
 	for {
 		select {
@@ -69,16 +78,18 @@ func (w *watcher) watch(ctx context.Context) {
 				Timestamp: time.Now(),
 				Error:     err,
 			}
-			// 错误后等待重试，避免频繁请求
+			// 错误后按指数退避等待重试，避免频繁请求
+			failures++
 			select {
 			case <-ctx.Done():
 				return
 			case <-w.stopCh:
 				return
-			case <-time.After(retryWaitTime):
+			case <-w.retry.After(failures):
 				continue
 			}
 		}
+		failures = 0
 
 		// 如果索引发生变化，说明有配置更新
 		if meta.LastIndex > w.lastIndex {
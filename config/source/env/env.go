@@ -113,6 +113,16 @@ func (s *Source) Load(ctx context.Context) (map[string]config.Value, error) {
 	return result, nil
 }
 
+// Locate 返回提供 key 的环境变量名，如 database.password -> APP_DATABASE_PASSWORD
+// 自定义 key 映射无法将该名称映射回 key 时返回空字符串
+func (s *Source) Locate(key string) string {
+	name := s.prefix + strings.ToUpper(strings.ReplaceAll(key, ".", s.separator))
+	if s.keyMapping(name) != key {
+		return ""
+	}
+	return name
+}
+
 // Watch 环境变量不支持监听
 func (s *Source) Watch() config.Watcher {
 	return nil
@@ -225,3 +225,31 @@ func TestSource_Name(t *testing.T) {
 		t.Errorf("Expected name 'env', got %s", source.Name())
 	}
 }
+
+func TestSource_Locate(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		key  string
+		want string
+	}{
+		{"with_prefix", []Option{WithPrefix("APP_")}, "database.password", "APP_DATABASE_PASSWORD"},
+		{"no_prefix", nil, "database.host", "DATABASE_HOST"},
+		{"custom_separator", []Option{WithPrefix("APP_"), WithSeparator("__")}, "database.host", "APP_DATABASE__HOST"},
+		{"prefix_pattern", []Option{WithPrefix("APP_")}, "cache.*", "APP_CACHE_*"},
+		{
+			"custom_mapping_not_invertible",
+			[]Option{WithKeyMapping(func(string) string { return "fixed" })},
+			"database.host",
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New(tt.opts...).Locate(tt.key); got != tt.want {
+				t.Errorf("Locate(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -114,20 +115,31 @@ func flatten(prefix string, data map[string]any, result map[string]config.Value)
 		if prefix != "" {
 			key = prefix + "." + k
 		}
+		flattenValue(key, v, result)
+	}
+}
 
-		switch val := v.(type) {
-		case map[string]any:
-			flatten(key, val, result)
-		case map[any]any:
-			// YAML 可能返回这种类型
-			converted := make(map[string]any)
-			for mk, mv := range val {
-				converted[fmt.Sprintf("%v", mk)] = mv
-			}
-			flatten(key, converted, result)
-		default:
-			result[key] = config.NewValueFromInterface(v)
+// flattenValue 扁平化单个值
+// 数组在保留原始值的同时按下标展开为 key.0、key.1 等，
+// 便于逐个元素寻址，并允许 APP_SERVERS_0_HOST 等高优先级配置覆盖单个元素
+func flattenValue(key string, v any, result map[string]config.Value) {
+	switch val := v.(type) {
+	case map[string]any:
+		flatten(key, val, result)
+	case map[any]any:
+		// YAML 可能返回这种类型
+		converted := make(map[string]any)
+		for mk, mv := range val {
+			converted[fmt.Sprintf("%v", mk)] = mv
 		}
+		flatten(key, converted, result)
+	case []any:
+		result[key] = config.NewValueFromInterface(v)
+		for i, item := range val {
+			flattenValue(key+"."+strconv.Itoa(i), item, result)
+		}
+	default:
+		result[key] = config.NewValueFromInterface(v)
 	}
 }
 
@@ -232,6 +232,51 @@ func TestSource_EdgeCases(t *testing.T) {
 		}
 	})
 
+	t.Run("array_indexed_keys", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		configPath := filepath.Join(tmpDir, "servers.yaml")
+
+		yamlContent := `
+servers:
+  - host: a
+    port: 1
+  - host: b
+    port: 2
+    tags: [x, y]
+`
+
+		if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+
+		source, err := New(configPath)
+		if err != nil {
+			t.Fatalf("Failed to create source: %v", err)
+		}
+
+		values, err := source.Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+
+		expected := map[string]string{
+			"servers.0.host":   "a",
+			"servers.0.port":   "1",
+			"servers.1.host":   "b",
+			"servers.1.tags.1": "y",
+		}
+		for key, want := range expected {
+			if val, ok := values[key]; !ok || val.String() != want {
+				t.Errorf("values[%s] = %v, want %v", key, val.String(), want)
+			}
+		}
+
+		// 原始数组仍然保留
+		if _, ok := values["servers"]; !ok {
+			t.Error("Array value should still be loaded as a whole")
+		}
+	})
+
 	t.Run("invalid_json", func(t *testing.T) {
 		tmpDir := t.TempDir()
 		configPath := filepath.Join(tmpDir, "invalid.json")
@@ -1,5 +1,7 @@
 package postgres
 
+import "github.com/CloudRoamer/aimo-libs/config"
+
 // Option PostgreSQL 配置源选项
 type Option func(*Source)
 
@@ -24,3 +26,19 @@ func WithPriority(p int) Option {
 		s.priority = p
 	}
 }
+
+// WithLazyConnect 创建时不检查数据库是否可达，连接失败由 Load 返回
+// 配合 config.PolicyFallback 和 config.WithSnapshot 使用，数据库不可达时服务仍可从快照启动
+func WithLazyConnect() Option {
+	return func(s *Source) {
+		s.lazy = true
+	}
+}
+
+// WithRetry 设置 New 连接检查失败时的重试策略
+// 策略不限制 MaxAttempts 和 MaxElapsed 时，应使用 NewWithContext 以便取消创建
+func WithRetry(policy config.RetryPolicy) Option {
+	return func(s *Source) {
+		s.retry = &policy
+	}
+}
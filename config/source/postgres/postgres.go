@@ -24,20 +24,23 @@ type Source struct {
 	keyCol   string
 	valueCol string
 	priority int
+	retry    *config.RetryPolicy // 创建时连接检查的重试策略
+	lazy     bool                // 创建时不检查连接，由 Load 报告不可达
 }
 
 // New 创建 PostgreSQL 配置源
+// 设置了不限次数和总时长的重试策略时会一直等到连接成功，需要取消时使用 NewWithContext
 func New(dsn string, opts ...Option) (*Source, error) {
+	return NewWithContext(context.Background(), dsn, opts...)
+}
+
+// NewWithContext 创建 PostgreSQL 配置源，ctx 用于取消创建时的连接检查及其重试等待
+func NewWithContext(ctx context.Context, dsn string, opts ...Option) (*Source, error) {
 	db, err := sql.Open("postgres", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	if err := db.Ping(); err != nil {
-		db.Close()
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
-	}
-
 	s := &Source{
 		db:       db,
 		table:    DefaultTable,
@@ -50,6 +53,21 @@ func New(dsn string, opts ...Option) (*Source, error) {
 		opt(s)
 	}
 
+	if s.lazy {
+		return s, nil
+	}
+
+	// 验证连接，设置了 WithRetry 时按策略重试
+	if s.retry != nil {
+		err = s.retry.Do(ctx, db.PingContext)
+	} else {
+		err = db.PingContext(ctx)
+	}
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
 	return s, nil
 }
 
@@ -2,7 +2,11 @@ package postgres
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/CloudRoamer/aimo-libs/config"
 )
 
 // TestNew 测试创建 PostgreSQL 配置源
@@ -112,6 +116,15 @@ func TestWithColumns(t *testing.T) {
 	}
 }
 
+// TestWithRetry 测试连接重试选项
+func TestWithRetry(t *testing.T) {
+	policy := config.RetryPolicy{MaxAttempts: 2, InitialDelay: time.Millisecond}
+	_, err := New("postgres://user@127.0.0.1:1/db?sslmode=disable&connect_timeout=1", WithRetry(policy))
+	if err == nil || !strings.Contains(err.Error(), "giving up after 2 attempts") {
+		t.Errorf("New() error = %v, want retries exhausted", err)
+	}
+}
+
 // TestClose 测试关闭连接
 func TestClose(t *testing.T) {
 	// 由于需要实际的数据库连接，跳过此测试
@@ -131,3 +144,45 @@ func BenchmarkSource_Load(b *testing.B) {
 		_, _ = s.Load(ctx)
 	}
 }
+
+// TestWithLazyConnect 测试数据库不可达时从快照启动
+func TestWithLazyConnect(t *testing.T) {
+	s, err := New("postgres://user@127.0.0.1:1/db?sslmode=disable&connect_timeout=1", WithLazyConnect())
+	if err != nil {
+		t.Fatalf("New() error = %v, want lazy connect", err)
+	}
+	defer s.Close()
+
+	snapshots := config.NewSnapshotCache(t.TempDir(), nil)
+	if err := snapshots.Save(s.Name(), map[string]config.Value{"feature.enabled": config.NewValue("true")}); err != nil {
+		t.Fatal(err)
+	}
+
+	mgr := config.NewManager()
+	mgr.AddSourceWith(s, config.WithPolicy(config.PolicyFallback), config.WithSnapshot(snapshots))
+	if err := mgr.Load(context.Background()); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !mgr.Config().GetBool("feature.enabled", false) {
+		t.Error("feature.enabled should come from snapshot")
+	}
+	if degraded := mgr.DegradedSources(); len(degraded) != 1 || !degraded[0].Stale {
+		t.Errorf("DegradedSources() = %+v, want stale postgres", degraded)
+	}
+}
+
+// TestNewWithContext 测试取消不限次数的连接重试
+func TestNewWithContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := NewWithContext(ctx, "postgres://user@127.0.0.1:1/db?sslmode=disable",
+		WithRetry(config.RetryPolicy{InitialDelay: time.Millisecond}))
+	if err == nil {
+		t.Fatal("NewWithContext() should fail")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("NewWithContext() took %v, want to stop when ctx is done", elapsed)
+	}
+}
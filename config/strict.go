@@ -0,0 +1,271 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// StrictMode 未知 key 检测模式
+type StrictMode int
+
+const (
+	StrictOff   StrictMode = iota // 不检测
+	StrictWarn                    // 检测并通过 Manager.UnknownKeys 报告，Load 正常返回
+	StrictError                   // 检测到未知 key 时 Load 失败
+)
+
+// maxSuggestions 每个未知 key 最多给出的建议数量
+const maxSuggestions = 3
+
+// UnknownKey 配置源中未声明的 key
+type UnknownKey struct {
+	Key         string   // 配置键
+	Source      string   // 提供该 key 的配置源
+	Suggestions []string // 编辑距离相近的已知 key
+}
+
+func (u UnknownKey) String() string {
+	s := fmt.Sprintf("%s (source: %s)", u.Key, u.Source)
+	if len(u.Suggestions) > 0 {
+		s += ": did you mean " + strings.Join(u.Suggestions, ", ") + "?"
+	}
+	return s
+}
+
+// UnknownKeysError 严格模式下检测到的未知 key
+type UnknownKeysError struct {
+	Keys []UnknownKey
+}
+
+func (e *UnknownKeysError) Error() string {
+	lines := make([]string, len(e.Keys))
+	for i, k := range e.Keys {
+		lines[i] = k.String()
+	}
+	return fmt.Sprintf("%v:\n%s", ErrUnknownKey, strings.Join(lines, "\n"))
+}
+
+// Is 使 errors.Is(err, ErrUnknownKey) 成立
+func (e *UnknownKeysError) Is(target error) bool {
+	return target == ErrUnknownKey
+}
+
+// knownKeys 已知 key 集合
+// 精确 key 与模式（path.Match 语法）分开保存，匹配时不区分大小写
+type knownKeys struct {
+	exact    map[string]string // 小写 key -> 原始 key
+	patterns []string
+}
+
+func newKnownKeys() *knownKeys {
+	return &knownKeys{exact: make(map[string]string)}
+}
+
+// add 添加已知 key，包含通配符的视为模式
+func (k *knownKeys) add(keys ...string) {
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if strings.ContainsAny(key, "*?[") {
+			k.patterns = append(k.patterns, strings.ToLower(key))
+			continue
+		}
+		k.exact[strings.ToLower(key)] = key
+	}
+}
+
+// empty 判断是否没有声明任何已知 key
+func (k *knownKeys) empty() bool {
+	return len(k.exact) == 0 && len(k.patterns) == 0
+}
+
+// known 判断 key 是否已知
+// 已知 key 的父级（嵌套 map 形式的值）和下标子级（key.0、key.1.host）同样视为已知
+func (k *knownKeys) known(key string) bool {
+	lower := strings.ToLower(key)
+	if _, ok := k.exact[lower]; ok {
+		return true
+	}
+	for _, p := range k.patterns {
+		if ok, _ := path.Match(p, lower); ok {
+			return true
+		}
+	}
+
+	for known := range k.exact {
+		if strings.HasPrefix(known, lower+".") {
+			return true
+		}
+		if rest, ok := strings.CutPrefix(lower, known+"."); ok {
+			index, _, _ := strings.Cut(rest, ".")
+			if _, err := strconv.Atoi(index); err == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// suggest 返回编辑距离最近的已知 key，距离超过 key 长度的 1/4（至少 2）时不建议
+func (k *knownKeys) suggest(key string) []string {
+	lower := strings.ToLower(key)
+	limit := max(2, len(lower)/4)
+
+	type candidate struct {
+		key      string
+		distance int
+	}
+	var candidates []candidate
+	for known, orig := range k.exact {
+		if d := levenshtein(lower, known); d <= limit {
+			candidates = append(candidates, candidate{orig, d})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].key < candidates[j].key
+	})
+
+	// 只保留距离最小的候选，避免把明显更远的 key 一并列出
+	result := make([]string, 0, maxSuggestions)
+	for i := 0; i < len(candidates) && i < maxSuggestions; i++ {
+		if candidates[i].distance > candidates[0].distance {
+			break
+		}
+		result = append(result, candidates[i].key)
+	}
+	return result
+}
+
+// findUnknownKeys 按配置源检查未知 key，结果按配置源和 key 排序
+func findUnknownKeys(sources []Source, values []map[string]Value, known *knownKeys) []UnknownKey {
+	var result []UnknownKey
+	for i, data := range values {
+		keys := make([]string, 0, len(data))
+		for key := range data {
+			if !known.known(key) {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			result = append(result, UnknownKey{
+				Key:         key,
+				Source:      sources[i].Name(),
+				Suggestions: known.suggest(key),
+			})
+		}
+	}
+	return result
+}
+
+// checkUnknownKeys 按严格模式检查各配置源加载的 key
+func (m *Manager) checkUnknownKeys(sources []Source, values []map[string]Value) ([]UnknownKey, error) {
+	if m.strictMode == StrictOff {
+		return nil, nil
+	}
+
+	known := newKnownKeys()
+	known.add(m.knownKeys...)
+	for _, info := range DefaultRegistry.Keys() {
+		known.add(info.Name)
+	}
+	for _, b := range m.bindings {
+		known.add(b.knownKeys()...)
+	}
+	if known.empty() {
+		return nil, nil
+	}
+
+	unknown := findUnknownKeys(sources, values, known)
+	if len(unknown) > 0 && m.strictMode == StrictError {
+		return nil, &UnknownKeysError{Keys: unknown}
+	}
+	return unknown, nil
+}
+
+// UnknownKeys 返回最近一次成功加载时检测到的未知 key（StrictWarn 模式）
+func (m *Manager) UnknownKeys() []UnknownKey {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]UnknownKey(nil), m.unknownKeys...)
+}
+
+// KeysOf 从结构体类型 T 推导 prefix 下的已知 key（按 mapstructure 标签）
+// map 与 interface 字段允许任意子 key，结构体切片的元素字段以 * 匹配下标
+func KeysOf[T any](prefix string) []string {
+	var keys []string
+	collectKeys(reflect.TypeOf((*T)(nil)).Elem(), prefix, &keys)
+	sort.Strings(keys)
+	return keys
+}
+
+// collectKeys 递归收集结构体字段对应的 key
+func collectKeys(rt reflect.Type, key string, keys *[]string) {
+	for rt.Kind() == reflect.Pointer {
+		rt = rt.Elem()
+	}
+
+	switch {
+	case rt == durationType || reflect.PointerTo(rt).Implements(textUnmarshalerType):
+		*keys = append(*keys, key)
+	case rt.Kind() == reflect.Struct:
+		for i := 0; i < rt.NumField(); i++ {
+			field := rt.Field(i)
+			if !field.IsExported() && !field.Anonymous {
+				continue
+			}
+			name, squash := parseTag(field)
+			if name == "-" {
+				continue
+			}
+			if squash {
+				collectKeys(field.Type, key, keys)
+				continue
+			}
+			collectKeys(field.Type, joinKey(key, name), keys)
+		}
+	case rt.Kind() == reflect.Map || rt.Kind() == reflect.Interface:
+		*keys = append(*keys, key, joinKey(key, "*"))
+	case rt.Kind() == reflect.Slice || rt.Kind() == reflect.Array:
+		*keys = append(*keys, key)
+		elem := rt.Elem()
+		for elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+		if elem.Kind() == reflect.Struct || elem.Kind() == reflect.Map || elem.Kind() == reflect.Interface {
+			collectKeys(elem, joinKey(key, "*"), keys)
+		}
+	default:
+		*keys = append(*keys, key)
+	}
+}
+
+// levenshtein 计算两个字符串的编辑距离
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
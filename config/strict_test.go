@@ -0,0 +1,185 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type strictDatabaseConfig struct {
+	Host    string         `mapstructure:"host"`
+	Port    int            `mapstructure:"port"`
+	Timeout time.Duration  `mapstructure:"timeout"`
+	Options map[string]any `mapstructure:"options"`
+}
+
+type strictAppConfig struct {
+	Database strictDatabaseConfig `mapstructure:"database"`
+	Replicas []struct {
+		Host string `mapstructure:"host"`
+	} `mapstructure:"replicas"`
+	Tags    []string `mapstructure:"tags"`
+	Ignored string   `mapstructure:"-"`
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"databse", "database", 1},
+		{"datbase", "database", 1},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			if got := levenshtein(tt.a, tt.b); got != tt.want {
+				t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeysOf(t *testing.T) {
+	want := []string{
+		"app.database.host",
+		"app.database.options",
+		"app.database.options.*",
+		"app.database.port",
+		"app.database.timeout",
+		"app.replicas",
+		"app.replicas.*.host",
+		"app.tags",
+	}
+	if got := KeysOf[strictAppConfig]("app"); !reflect.DeepEqual(got, want) {
+		t.Errorf("KeysOf() = %v, want %v", got, want)
+	}
+}
+
+func TestKnownKeys(t *testing.T) {
+	known := newKnownKeys()
+	known.add(KeysOf[strictAppConfig]("")...)
+	known.add("plugins.*")
+
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"database.host", true},
+		{"DATABASE.HOST", true},
+		{"database", true},
+		{"database.options.pool.size", true},
+		{"replicas.0.host", true},
+		{"tags.1", true},
+		{"plugins.auth", true},
+		{"databse.host", false},
+		{"database.user", false},
+		{"tags.first", false},
+		{"ignored", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.ReplaceAll(tt.key, ".", "_"), func(t *testing.T) {
+			if got := known.known(tt.key); got != tt.want {
+				t.Errorf("known(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("suggest", func(t *testing.T) {
+		if got := known.suggest("databse.host"); len(got) == 0 || got[0] != "database.host" {
+			t.Errorf("suggest() = %v, want database.host first", got)
+		}
+		if got := known.suggest("completely.different"); len(got) != 0 {
+			t.Errorf("suggest() = %v, want none", got)
+		}
+	})
+}
+
+func TestManager_StrictKeys(t *testing.T) {
+	newSources := func() []Source {
+		return []Source{
+			&mockSource{
+				name:     "file",
+				priority: 60,
+				data: map[string]Value{
+					"database.host": NewValue("localhost"),
+					"databse.port":  NewValue("5432"),
+				},
+			},
+			&mockSource{
+				name:     "env",
+				priority: 100,
+				data:     map[string]Value{"datbase.host": NewValue("db")},
+			},
+		}
+	}
+	known := WithKnownKeys(KeysOf[strictDatabaseConfig]("database")...)
+
+	t.Run("warn", func(t *testing.T) {
+		m := NewManager(WithStrictKeys(StrictWarn), known)
+		m.AddSource(newSources()...)
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		unknown := m.UnknownKeys()
+		if len(unknown) != 2 {
+			t.Fatalf("UnknownKeys() = %v, want 2 entries", unknown)
+		}
+		if unknown[0].Key != "databse.port" || unknown[0].Source != "file" {
+			t.Errorf("unknown[0] = %+v, want databse.port from file", unknown[0])
+		}
+		if unknown[1].Key != "datbase.host" || unknown[1].Source != "env" {
+			t.Errorf("unknown[1] = %+v, want datbase.host from env", unknown[1])
+		}
+		if got := unknown[1].String(); got != "datbase.host (source: env): did you mean database.host?" {
+			t.Errorf("String() = %q", got)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		m := NewManager(WithStrictKeys(StrictError), known)
+		m.AddSource(newSources()...)
+
+		err := m.Load(context.Background())
+		var ue *UnknownKeysError
+		if !errors.As(err, &ue) || !errors.Is(err, ErrUnknownKey) {
+			t.Fatalf("Load() error = %v, want *UnknownKeysError", err)
+		}
+		if len(ue.Keys) != 2 || !strings.Contains(err.Error(), "databse.port (source: file): did you mean database.port?") {
+			t.Errorf("error %q should list unknown keys per source", err)
+		}
+		if m.Config().Has("database.host") {
+			t.Error("rejected config should not be applied")
+		}
+	})
+
+	t.Run("bind_derived", func(t *testing.T) {
+		m := NewManager(WithStrictKeys(StrictError))
+		if _, err := Bind[strictDatabaseConfig](m, "database"); err != nil {
+			t.Fatalf("Bind() error = %v", err)
+		}
+		m.AddSource(newSources()...)
+		if err := m.Load(context.Background()); !errors.Is(err, ErrUnknownKey) {
+			t.Errorf("Load() error = %v, want ErrUnknownKey", err)
+		}
+	})
+
+	t.Run("off", func(t *testing.T) {
+		m := NewManager(known)
+		m.AddSource(newSources()...)
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if len(m.UnknownKeys()) != 0 {
+			t.Errorf("UnknownKeys() = %v, want none when strict mode is off", m.UnknownKeys())
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"slices"
+)
+
+// SubscribeFunc 订阅回调，changes 只包含匹配订阅模式的变化
+type SubscribeFunc func(changes ChangeSet)
+
+// subscription 单个 key 模式订阅
+type subscription struct {
+	pattern string
+	fn      SubscribeFunc
+}
+
+// Subscribe 订阅匹配 pattern 的配置键变化（path.Match 语法，如 "database.*"）
+// 热更新后只有匹配的 key 实际发生变化时才调用 fn
+// 返回的函数用于取消订阅
+func (m *Manager) Subscribe(pattern string, fn SubscribeFunc) (func(), error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid subscribe pattern %q: %w", pattern, err)
+	}
+
+	sub := &subscription{pattern: pattern, fn: fn}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.subscriptions = append(m.subscriptions, sub)
+
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.subscriptions = slices.DeleteFunc(m.subscriptions, func(s *subscription) bool {
+			return s == sub
+		})
+	}, nil
+}
+
+// notifySubscribers 通知匹配变化的订阅者
+func (m *Manager) notifySubscribers(changes ChangeSet) {
+	if changes.Empty() {
+		return
+	}
+
+	m.mu.RLock()
+	subs := slices.Clone(m.subscriptions)
+	m.mu.RUnlock()
+
+	for _, sub := range subs {
+		if matched := changes.Match(sub.pattern); !matched.Empty() {
+			sub.fn(matched)
+		}
+	}
+}
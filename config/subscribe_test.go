@@ -0,0 +1,113 @@
+package config
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestManager_Subscribe(t *testing.T) {
+	watcher := &mockWatcher{}
+	source := &mockSource{
+		name: "file",
+		data: map[string]Value{
+			"database.host": NewValue("a"),
+			"server.port":   NewValue("8080"),
+		},
+		watcher: watcher,
+	}
+
+	m := NewManager()
+	m.AddSource(source)
+	if err := m.Load(context.Background()); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	dbChanges := make(chan ChangeSet, 10)
+	if _, err := m.Subscribe("database.*", func(cs ChangeSet) { dbChanges <- cs }); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	cacheChanges := make(chan ChangeSet, 10)
+	unsubscribe, err := m.Subscribe("cache.*", func(cs ChangeSet) { cacheChanges <- cs })
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	reloaded := make(chan struct{}, 10)
+	m.OnChange(func(event Event, oldConfig, newConfig Config) { reloaded <- struct{}{} })
+	if err := m.Watch(); err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer m.Close()
+
+	reload := func(data map[string]Value) {
+		t.Helper()
+		source.data = data
+		watcher.eventCh <- Event{Type: EventTypeUpdate, Source: "file"}
+		select {
+		case <-reloaded:
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for reload")
+		}
+	}
+
+	// 只有 server.port 变化，database.* 订阅不触发
+	reload(map[string]Value{
+		"database.host": NewValue("a"),
+		"server.port":   NewValue("9090"),
+	})
+
+	// database.host 修改，database.user 新增
+	reload(map[string]Value{
+		"database.host": NewValue("b"),
+		"database.user": NewValue("app"),
+		"server.port":   NewValue("9090"),
+	})
+
+	select {
+	case cs := <-dbChanges:
+		if len(cs.Modified) != 1 || cs.Modified[0].Key != "database.host" ||
+			cs.Modified[0].Old.String() != "a" || cs.Modified[0].New.String() != "b" {
+			t.Errorf("Modified = %+v", cs.Modified)
+		}
+		if len(cs.Added) != 1 || cs.Added[0].Key != "database.user" {
+			t.Errorf("Added = %+v", cs.Added)
+		}
+		if len(cs.Removed) != 0 {
+			t.Errorf("Removed = %+v, want none", cs.Removed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("database.* subscriber was not called")
+	}
+
+	select {
+	case cs := <-dbChanges:
+		t.Errorf("unexpected extra notification: %+v", cs)
+	default:
+	}
+
+	// 取消订阅后不再通知
+	unsubscribe()
+	reload(map[string]Value{"cache.ttl": NewValue("1m")})
+	select {
+	case cs := <-cacheChanges:
+		t.Errorf("unsubscribed callback was called: %+v", cs)
+	default:
+	}
+
+	select {
+	case cs := <-dbChanges:
+		if len(cs.Removed) != 2 {
+			t.Errorf("Removed = %+v, want database.host and database.user", cs.Removed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("database.* subscriber was not called for removed keys")
+	}
+}
+
+func TestManager_Subscribe_InvalidPattern(t *testing.T) {
+	m := NewManager()
+	if _, err := m.Subscribe("database.[", func(ChangeSet) {}); err == nil {
+		t.Error("Subscribe() should reject invalid pattern")
+	}
+}
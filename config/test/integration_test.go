@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 
@@ -127,3 +128,128 @@ func TestManager_MultipleLoads(t *testing.T) {
 
 	defer mgr.Close()
 }
+
+func TestManager_ArrayElementOverride(t *testing.T) {
+	os.Setenv("ARR_SERVERS_1_HOST", "env.host")
+	defer os.Unsetenv("ARR_SERVERS_1_HOST")
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	yamlContent := `
+servers:
+  - host: a.local
+    port: 8001
+  - host: b.local
+    port: 8002
+`
+	if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	fileSource, err := file.New(configPath)
+	if err != nil {
+		t.Fatalf("Failed to create file source: %v", err)
+	}
+
+	mgr := config.NewManager()
+	mgr.AddSource(fileSource, env.New(env.WithPrefix("ARR_")))
+	defer mgr.Close()
+
+	if err := mgr.Load(context.Background()); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	type server struct {
+		Host string `mapstructure:"host"`
+		Port int    `mapstructure:"port"`
+	}
+	var servers []server
+	if err := mgr.Config().UnmarshalKey("servers", &servers); err != nil {
+		t.Fatalf("UnmarshalKey failed: %v", err)
+	}
+
+	if len(servers) != 2 {
+		t.Fatalf("Expected 2 servers, got %d", len(servers))
+	}
+	if servers[0].Host != "a.local" || servers[0].Port != 8001 {
+		t.Errorf("Unexpected servers[0]: %+v", servers[0])
+	}
+	if servers[1].Host != "env.host" || servers[1].Port != 8002 {
+		t.Errorf("Expected servers[1].host overridden by env, got %+v", servers[1])
+	}
+}
+
+func TestManager_ArrayWholeOverride(t *testing.T) {
+	os.Setenv("PX_HOSTS", "c,d,e")
+	defer os.Unsetenv("PX_HOSTS")
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("hosts: [a, b]\n"), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	fileSource, err := file.New(configPath)
+	if err != nil {
+		t.Fatalf("Failed to create file source: %v", err)
+	}
+
+	mgr := config.NewManager()
+	mgr.AddSource(fileSource, env.New(env.WithPrefix("PX_")))
+	defer mgr.Close()
+
+	if err := mgr.Load(context.Background()); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	cfg := mgr.Config()
+	want := []string{"c", "d", "e"}
+
+	if got := cfg.GetStringSlice("hosts", nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetStringSlice() = %v, want %v", got, want)
+	}
+	if got, err := config.Get[[]string](cfg, "hosts"); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Get[[]string]() = %v, %v, want %v", got, err, want)
+	}
+	var hosts []string
+	if err := cfg.UnmarshalKey("hosts", &hosts); err != nil || !reflect.DeepEqual(hosts, want) {
+		t.Errorf("UnmarshalKey() = %v, %v, want %v", hosts, err, want)
+	}
+	if got := cfg.AllSettings()["hosts"]; got != "c,d,e" {
+		t.Errorf("AllSettings()[hosts] = %v, want env value", got)
+	}
+	if p, ok := mgr.Explain("hosts"); !ok || p.Source != "env" {
+		t.Errorf("Explain(hosts) = %+v, want env", p)
+	}
+}
+
+func TestManager_ScalarKeepsChildren(t *testing.T) {
+	os.Setenv("PX_LOG", "debug")
+	defer os.Unsetenv("PX_LOG")
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("log:\n  level: info\n  format: json\n"), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	fileSource, err := file.New(configPath)
+	if err != nil {
+		t.Fatalf("Failed to create file source: %v", err)
+	}
+
+	mgr := config.NewManager()
+	mgr.AddSource(fileSource, env.New(env.WithPrefix("PX_")))
+	defer mgr.Close()
+
+	if err := mgr.Load(context.Background()); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	cfg := mgr.Config()
+
+	if got := cfg.GetString("log.level", ""); got != "info" {
+		t.Errorf("log.level = %q, want info", got)
+	}
+	if got := cfg.GetString("log.format", ""); got != "json" {
+		t.Errorf("log.format = %q, want json", got)
+	}
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+)
+
+// buildTree 根据扁平化的 key 重建 prefix 下的嵌套 map
+// prefix 为空时重建整个配置；值为嵌套 map 的 key 会先展开再合并
+// 同一路径既是叶子值又有子 key 时，子 key 优先
+// 子级全部为数组下标（0, 1, ...）的节点还原为 []any
+func buildTree(data map[string]Value, prefix string) map[string]any {
+	p := ""
+	if prefix != "" {
+		p = prefix + "."
+	}
+
+	flat := make(map[string]Value)
+	for k, v := range data {
+		var rel string
+		switch {
+		case k == prefix && prefix != "":
+			rel = ""
+		case strings.HasPrefix(k, p):
+			rel = k[len(p):]
+		default:
+			continue
+		}
+
+		if nested, ok := nestedMap(v.Raw()); ok {
+			flattenMap(rel, nested, v.Sensitive(), flat)
+			continue
+		}
+		if rel != "" {
+			flat[rel] = v
+		}
+	}
+
+	tree := make(map[string]any)
+	for k, v := range flat {
+		insertTree(tree, strings.Split(k, "."), v.Raw())
+	}
+	for k, v := range tree {
+		tree[k] = restoreLists(v)
+	}
+	return tree
+}
+
+// restoreLists 将子级全部为数组下标的 map 递归还原为 []any
+func restoreLists(node any) any {
+	m, ok := node.(map[string]any)
+	if !ok {
+		return node
+	}
+
+	for k, v := range m {
+		m[k] = restoreLists(v)
+	}
+
+	names := make([]string, 0, len(m))
+	for k := range m {
+		names = append(names, k)
+	}
+	indices, size, ok := listIndices(names)
+	if !ok {
+		return m
+	}
+
+	list := make([]any, size)
+	for i, name := range names {
+		list[indices[i]] = m[name]
+	}
+	return list
+}
+
+// listIndices 判断子级名称是否恰好为连续的数组下标 0..n-1
+// 返回与 names 一一对应的下标以及数组长度；稀疏下标不视为数组，避免异常下标导致超大分配
+func listIndices(names []string) ([]int, int, bool) {
+	if len(names) == 0 {
+		return nil, 0, false
+	}
+	size := 0
+	indices := make([]int, len(names))
+	for i, name := range names {
+		n, err := strconv.Atoi(name)
+		if err != nil || n < 0 || strconv.Itoa(n) != name {
+			return nil, 0, false
+		}
+		indices[i] = n
+		size = max(size, n+1)
+	}
+	if size != len(names) {
+		return nil, 0, false
+	}
+	return indices, size, true
+}
+
+// insertTree 按路径将值插入嵌套 map
+func insertTree(tree map[string]any, path []string, val any) {
+	node := tree
+	for _, seg := range path[:len(path)-1] {
+		child, ok := node[seg].(map[string]any)
+		if !ok {
+			child = make(map[string]any)
+			node[seg] = child
+		}
+		node = child
+	}
+
+	last := path[len(path)-1]
+	if _, isMap := node[last].(map[string]any); isMap {
+		return
+	}
+	node[last] = val
+}
+
+// flattenTree 将嵌套 map 展开为相对 key 的字符串映射
+func flattenTree(prefix string, tree map[string]any, result map[string]string) {
+	for k, v := range tree {
+		key := joinKey(prefix, k)
+		if nested, ok := v.(map[string]any); ok {
+			flattenTree(key, nested, result)
+			continue
+		}
+		result[key] = NewValueFromInterface(v).String()
+	}
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ConfigValidator 校验候选配置，返回的错误描述违规项
+// 可以使用 errors.Join 返回多个违规项
+type ConfigValidator func(cfg Config) error
+
+// validateConfig 依次执行所有校验函数，汇总全部违规项
+// 违规项按 provenance 标注提供该值的配置源
+func validateConfig(cfg Config, validators []ConfigValidator, provenance map[string]Provenance) error {
+	var errs []error
+	for _, v := range validators {
+		if err := v(cfg); err != nil {
+			errs = append(errs, annotateSources(err, provenance))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrValidationFailed, errors.Join(errs...))
+	}
+	return nil
+}
@@ -0,0 +1,379 @@
+package config
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// validateTagName 结构体校验规则标签名
+//
+// 支持的规则（逗号分隔）:
+//
+//	required                  值不能为零值（指针不能为 nil）
+//	omitempty                 值为零值时跳过其余规则
+//	min=N / max=N             数值范围；字符串、切片、map 为长度范围；time.Duration 使用 "1s" 形式
+//	oneof=a b c               值必须为空格分隔的候选之一
+//	eqfield=F / nefield=F     与同一结构体中的字段 F 相等 / 不相等
+//	gtfield=F / gtefield=F    大于 / 大于等于字段 F
+//	ltfield=F / ltefield=F    小于 / 小于等于字段 F
+//
+// 字段 F 可以是 Go 字段名或 mapstructure 标签名
+const validateTagName = "validate"
+
+// Violation 单个校验违规项
+type Violation struct {
+	Key     string // 配置键
+	Rule    string // 违反的规则，如 required、max=65535
+	Message string // 违规描述
+	Source  string // 提供该值的配置源，未知或缺失时为空
+}
+
+func (v Violation) String() string {
+	if v.Source != "" {
+		return fmt.Sprintf("%s (source: %s): %s", v.Key, v.Source, v.Message)
+	}
+	return fmt.Sprintf("%s: %s", v.Key, v.Message)
+}
+
+// ValidationError 汇总的结构体校验错误
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		lines[i] = v.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Is 使 errors.Is(err, ErrValidationFailed) 成立
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidationFailed
+}
+
+// annotate 根据来源信息填充违规项的配置源
+func (e *ValidationError) annotate(provenance map[string]Provenance) {
+	for i, v := range e.Violations {
+		if v.Source != "" {
+			continue
+		}
+		if p, ok := provenance[v.Key]; ok {
+			e.Violations[i].Source = p.Source
+			continue
+		}
+		for key, p := range provenance {
+			if strings.EqualFold(key, v.Key) {
+				e.Violations[i].Source = p.Source
+				break
+			}
+		}
+	}
+}
+
+// annotateSources 为错误链中所有 ValidationError 填充配置源
+// fmt.Errorf 会立即格式化错误信息，需要在包装之前调用
+func annotateSources(err error, provenance map[string]Provenance) error {
+	switch e := err.(type) {
+	case *ValidationError:
+		e.annotate(provenance)
+	case interface{ Unwrap() error }:
+		annotateSources(e.Unwrap(), provenance)
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			annotateSources(inner, provenance)
+		}
+	}
+	return err
+}
+
+// ValidateStruct 按 validate 标签校验结构体，key 为结构体对应的配置前缀
+// 返回 *ValidationError，包含全部违规项
+func ValidateStruct(key string, v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("config: validate target must be a struct, got %T", v)
+	}
+
+	sv := &structValidator{}
+	sv.validateStruct(key, rv)
+	if len(sv.violations) > 0 {
+		return &ValidationError{Violations: sv.violations}
+	}
+	return nil
+}
+
+// StructValidator 返回将 key 前缀下的配置解码为 T 并按 validate 标签校验的 ConfigValidator
+func StructValidator[T any](key string) ConfigValidator {
+	return func(cfg Config) error {
+		var v T
+		if err := cfg.Unmarshal(key, &v); err != nil {
+			return err
+		}
+		return ValidateStruct(key, &v)
+	}
+}
+
+// structValidator 递归收集结构体校验违规项
+type structValidator struct {
+	violations []Violation
+}
+
+func (sv *structValidator) add(key, rule, format string, args ...any) {
+	sv.violations = append(sv.violations, Violation{Key: key, Rule: rule, Message: fmt.Sprintf(format, args...)})
+}
+
+// validateStruct 校验结构体的每个字段并递归进入嵌套结构
+func (sv *structValidator) validateStruct(key string, rv reflect.Value) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		name, squash := parseTag(field)
+		if name == "-" {
+			continue
+		}
+
+		fieldKey := joinKey(key, name)
+		if squash {
+			fieldKey = key
+		}
+		fv := rv.Field(i)
+
+		if tag := field.Tag.Get(validateTagName); tag != "" && tag != "-" {
+			sv.validateField(key, fieldKey, fv, tag, rv)
+		}
+		sv.walk(fieldKey, fv)
+	}
+}
+
+// walk 递归进入嵌套结构体、切片和 map
+func (sv *structValidator) walk(key string, rv reflect.Value) {
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		if rv.Type() == reflect.TypeOf(time.Time{}) {
+			return
+		}
+		sv.validateStruct(key, rv)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			sv.walk(joinKey(key, strconv.Itoa(i)), rv.Index(i))
+		}
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return
+		}
+		iter := rv.MapRange()
+		for iter.Next() {
+			sv.walk(joinKey(key, iter.Key().String()), iter.Value())
+		}
+	}
+}
+
+// validateField 按标签规则校验单个字段
+func (sv *structValidator) validateField(parentKey, key string, fv reflect.Value, tag string, parent reflect.Value) {
+	rules := strings.Split(tag, ",")
+	for _, rule := range rules {
+		if strings.TrimSpace(rule) == "omitempty" && fv.IsZero() {
+			return
+		}
+	}
+
+	for _, rule := range rules {
+		rule = strings.TrimSpace(rule)
+		name, param, _ := strings.Cut(rule, "=")
+
+		switch name {
+		case "", "omitempty":
+		case "required":
+			if fv.IsZero() {
+				sv.add(key, rule, "is required")
+			}
+		case "min", "max":
+			sv.checkRange(key, rule, name, param, fv)
+		case "oneof":
+			options := strings.Fields(param)
+			s, ok := scalarString(fv)
+			if ok && !slices.Contains(options, s) {
+				sv.add(key, rule, "must be one of [%s], got %q", strings.Join(options, " "), s)
+			}
+		case "eqfield", "nefield", "gtfield", "gtefield", "ltfield", "ltefield":
+			sv.checkField(parentKey, key, rule, name, param, fv, parent)
+		default:
+			sv.add(key, rule, "unknown validation rule %q", name)
+		}
+	}
+}
+
+// checkRange 校验 min/max 规则
+func (sv *structValidator) checkRange(key, rule, name, param string, fv reflect.Value) {
+	fv, ok := deref(fv)
+	if !ok {
+		return
+	}
+
+	var actual, limit float64
+	var err error
+	unit := ""
+
+	switch {
+	case fv.Type() == durationType:
+		var d time.Duration
+		d, err = time.ParseDuration(param)
+		actual, limit = float64(fv.Int()), float64(d)
+	case fv.Kind() == reflect.String || fv.Kind() == reflect.Slice || fv.Kind() == reflect.Map || fv.Kind() == reflect.Array:
+		limit, err = strconv.ParseFloat(param, 64)
+		actual = float64(fv.Len())
+		unit = "length "
+	default:
+		n, isNumber := numberOf(fv)
+		if !isNumber {
+			sv.add(key, rule, "rule %s is not supported for %s", name, fv.Type())
+			return
+		}
+		limit, err = strconv.ParseFloat(param, 64)
+		actual = n
+	}
+	if err != nil {
+		sv.add(key, rule, "invalid %s parameter %q", name, param)
+		return
+	}
+
+	got := strconv.FormatFloat(actual, 'f', -1, 64)
+	if fv.Type() == durationType {
+		got = time.Duration(actual).String()
+	}
+	switch {
+	case name == "min" && actual < limit:
+		sv.add(key, rule, "%smust be >= %s, got %s", unit, param, got)
+	case name == "max" && actual > limit:
+		sv.add(key, rule, "%smust be <= %s, got %s", unit, param, got)
+	}
+}
+
+// checkField 校验跨字段比较规则
+func (sv *structValidator) checkField(parentKey, key, rule, name, param string, fv, parent reflect.Value) {
+	other, otherName, found := siblingField(parent, param)
+	if !found {
+		sv.add(key, rule, "unknown field %q", param)
+		return
+	}
+	otherKey := joinKey(parentKey, otherName)
+
+	a, aok := deref(fv)
+	b, bok := deref(other)
+	if !aok || !bok {
+		return
+	}
+
+	var order int
+	if x, ok := numberOf(a); ok {
+		y, ok := numberOf(b)
+		if !ok {
+			sv.add(key, rule, "cannot compare %s with %s", a.Type(), b.Type())
+			return
+		}
+		order = cmp.Compare(x, y)
+	} else if a.Kind() == reflect.String && b.Kind() == reflect.String {
+		order = strings.Compare(a.String(), b.String())
+	} else {
+		sv.add(key, rule, "cannot compare %s with %s", a.Type(), b.Type())
+		return
+	}
+
+	ok, relation := true, ""
+	switch name {
+	case "eqfield":
+		ok, relation = order == 0, "equal to"
+	case "nefield":
+		ok, relation = order != 0, "different from"
+	case "gtfield":
+		ok, relation = order > 0, "greater than"
+	case "gtefield":
+		ok, relation = order >= 0, "greater than or equal to"
+	case "ltfield":
+		ok, relation = order < 0, "less than"
+	case "ltefield":
+		ok, relation = order <= 0, "less than or equal to"
+	}
+	if !ok {
+		sv.add(key, rule, "must be %s %s", relation, otherKey)
+	}
+}
+
+// siblingField 按 Go 字段名或 mapstructure 标签名查找同一结构体中的字段
+func siblingField(parent reflect.Value, name string) (reflect.Value, string, bool) {
+	rt := parent.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tagName, _ := parseTag(field)
+		if field.Name == name || tagName == name {
+			return parent.Field(i), tagName, true
+		}
+	}
+	return reflect.Value{}, "", false
+}
+
+// deref 解引用指针，nil 指针返回 false
+func deref(rv reflect.Value) (reflect.Value, bool) {
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return rv, false
+		}
+		rv = rv.Elem()
+	}
+	return rv, true
+}
+
+// numberOf 将数值类型转换为 float64
+func numberOf(rv reflect.Value) (float64, bool) {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// scalarString 返回字符串、数值、布尔值的字符串形式
+func scalarString(rv reflect.Value) (string, bool) {
+	rv, ok := deref(rv)
+	if !ok {
+		return "", false
+	}
+	if rv.Kind() == reflect.String {
+		return rv.String(), true
+	}
+	if rv.Kind() == reflect.Bool {
+		return strconv.FormatBool(rv.Bool()), true
+	}
+	if n, ok := numberOf(rv); ok {
+		return strconv.FormatFloat(n, 'f', -1, 64), true
+	}
+	return "", false
+}
@@ -0,0 +1,199 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type validateServerConfig struct {
+	Host         string        `mapstructure:"host" validate:"required"`
+	Port         int           `mapstructure:"port" validate:"min=1,max=65535"`
+	LogLevel     string        `mapstructure:"log_level" validate:"oneof=debug info warn"`
+	ReadTimeout  time.Duration `mapstructure:"read_timeout" validate:"min=1s,ltfield=WriteTimeout"`
+	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+	Tags         []string      `mapstructure:"tags" validate:"omitempty,min=2"`
+}
+
+type validateReplicaConfig struct {
+	Host string `mapstructure:"host" validate:"required"`
+}
+
+type validateAppConfig struct {
+	Server   validateServerConfig    `mapstructure:"server"`
+	Replicas []validateReplicaConfig `mapstructure:"replicas"`
+}
+
+func TestValidateStruct(t *testing.T) {
+	valid := func() validateServerConfig {
+		return validateServerConfig{
+			Host:         "localhost",
+			Port:         8080,
+			LogLevel:     "info",
+			ReadTimeout:  5 * time.Second,
+			WriteTimeout: 10 * time.Second,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(*validateServerConfig)
+		wantKeys []string
+		wantMsg  string
+	}{
+		{"valid", func(c *validateServerConfig) {}, nil, ""},
+		{"required", func(c *validateServerConfig) { c.Host = "" }, []string{"server.host"}, "is required"},
+		{"min", func(c *validateServerConfig) { c.Port = 0 }, []string{"server.port"}, "must be >= 1, got 0"},
+		{"max", func(c *validateServerConfig) { c.Port = 70000 }, []string{"server.port"}, "must be <= 65535, got 70000"},
+		{"oneof", func(c *validateServerConfig) { c.LogLevel = "trace" }, []string{"server.log_level"}, "must be one of [debug info warn]"},
+		{"duration_min", func(c *validateServerConfig) { c.ReadTimeout = time.Millisecond }, []string{"server.read_timeout"}, "must be >= 1s"},
+		{"cross_field", func(c *validateServerConfig) { c.ReadTimeout = 20 * time.Second }, []string{"server.read_timeout"}, "must be less than server.write_timeout"},
+		{"omitempty_skips_zero", func(c *validateServerConfig) { c.Tags = nil }, nil, ""},
+		{"length_min", func(c *validateServerConfig) { c.Tags = []string{"a"} }, []string{"server.tags"}, "length must be >= 2"},
+		{
+			"aggregated",
+			func(c *validateServerConfig) { c.Host = ""; c.Port = -1; c.LogLevel = "x" },
+			[]string{"server.host", "server.port", "server.log_level"},
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(&cfg)
+
+			err := ValidateStruct("server", &cfg)
+			if len(tt.wantKeys) == 0 {
+				if err != nil {
+					t.Fatalf("ValidateStruct() error = %v", err)
+				}
+				return
+			}
+
+			var ve *ValidationError
+			if !errors.As(err, &ve) {
+				t.Fatalf("ValidateStruct() error = %v, want *ValidationError", err)
+			}
+			if !errors.Is(err, ErrValidationFailed) {
+				t.Error("ValidationError should match ErrValidationFailed")
+			}
+			if len(ve.Violations) != len(tt.wantKeys) {
+				t.Fatalf("Violations = %v, want keys %v", ve.Violations, tt.wantKeys)
+			}
+			for i, key := range tt.wantKeys {
+				if ve.Violations[i].Key != key {
+					t.Errorf("Violations[%d].Key = %q, want %q", i, ve.Violations[i].Key, key)
+				}
+			}
+			if tt.wantMsg != "" && !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("error %q should contain %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestValidateStruct_Nested(t *testing.T) {
+	cfg := validateAppConfig{
+		Server:   validateServerConfig{Host: "h", Port: 1, LogLevel: "info", ReadTimeout: time.Second, WriteTimeout: 2 * time.Second},
+		Replicas: []validateReplicaConfig{{Host: "r0"}, {}},
+	}
+
+	var ve *ValidationError
+	if err := ValidateStruct("", cfg); !errors.As(err, &ve) {
+		t.Fatalf("ValidateStruct() error = %v", err)
+	}
+	if len(ve.Violations) != 1 || ve.Violations[0].Key != "replicas.1.host" {
+		t.Errorf("Violations = %v, want replicas.1.host", ve.Violations)
+	}
+}
+
+func TestValidateStruct_InvalidRules(t *testing.T) {
+	type bad struct {
+		A int    `validate:"between=1"`
+		B int    `validate:"max=abc"`
+		C int    `validate:"ltfield=Missing"`
+		D string `validate:"gtfield=A"`
+	}
+
+	var ve *ValidationError
+	if err := ValidateStruct("", bad{}); !errors.As(err, &ve) {
+		t.Fatalf("ValidateStruct() error = %v", err)
+	}
+	if len(ve.Violations) != 4 {
+		t.Errorf("Violations = %v, want 4 rule errors", ve.Violations)
+	}
+}
+
+func TestManager_StructValidation(t *testing.T) {
+	file := &mockSource{
+		name:     "file",
+		priority: 60,
+		data: map[string]Value{
+			"server.host":          NewValue("localhost"),
+			"server.port":          NewValue("8080"),
+			"server.log_level":     NewValue("info"),
+			"server.read_timeout":  NewValue("5s"),
+			"server.write_timeout": NewValue("10s"),
+		},
+	}
+	env := &mockSource{
+		name:     "env",
+		priority: 100,
+		data: map[string]Value{
+			"server.port":      NewValue("99999"),
+			"server.log_level": NewValue("verbose"),
+		},
+	}
+
+	t.Run("struct_validator", func(t *testing.T) {
+		m := NewManager(WithConfigValidator(StructValidator[validateServerConfig]("server")))
+		m.AddSource(file, env)
+
+		err := m.Load(context.Background())
+		if !errors.Is(err, ErrValidationFailed) {
+			t.Fatalf("Load() error = %v, want ErrValidationFailed", err)
+		}
+		for _, want := range []string{
+			"server.port (source: env): must be <= 65535",
+			"server.log_level (source: env): must be one of",
+		} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("error %q should contain %q", err, want)
+			}
+		}
+	})
+
+	t.Run("bind", func(t *testing.T) {
+		m := NewManager()
+		m.AddSource(file)
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		b, err := Bind[validateServerConfig](m, "server")
+		if err != nil {
+			t.Fatalf("Bind() error = %v", err)
+		}
+
+		m.AddSource(env)
+		err = m.Load(context.Background())
+		if !errors.Is(err, ErrValidationFailed) {
+			t.Fatalf("Load() error = %v, want ErrValidationFailed", err)
+		}
+		if !strings.Contains(err.Error(), "server.port (source: env)") {
+			t.Errorf("error %q should name the source", err)
+		}
+		if b.Load().Port != 8080 || m.Config().GetInt("server.port", 0) != 8080 {
+			t.Error("rejected reload should keep previous config and binding")
+		}
+	})
+
+	t.Run("bind_before_load", func(t *testing.T) {
+		m := NewManager()
+		if _, err := Bind[validateServerConfig](m, "server"); err != nil {
+			t.Errorf("Bind() before Load() should not validate zero value: %v", err)
+		}
+	})
+}
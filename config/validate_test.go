@@ -0,0 +1,133 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// portValidator 要求 server.port 在 1-65535 之间
+func portValidator(cfg Config) error {
+	port, err := cfg.GetIntE("server.port")
+	if err != nil {
+		return err
+	}
+	if port < 1 || port > 65535 {
+		return fmt.Errorf("server.port: %d out of range 1-65535", port)
+	}
+	return nil
+}
+
+// hostValidator 要求 database.host 非空
+func hostValidator(cfg Config) error {
+	if cfg.GetString("database.host", "") == "" {
+		return errors.New("database.host: must not be empty")
+	}
+	return nil
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      map[string]Value
+		wantErr   bool
+		wantParts []string
+	}{
+		{
+			name: "valid",
+			data: map[string]Value{
+				"server.port":   NewValue("8080"),
+				"database.host": NewValue("db"),
+			},
+		},
+		{
+			name:      "single_violation",
+			data:      map[string]Value{"server.port": NewValue("0"), "database.host": NewValue("db")},
+			wantErr:   true,
+			wantParts: []string{"server.port: 0 out of range"},
+		},
+		{
+			name:      "all_violations_reported",
+			data:      map[string]Value{"server.port": NewValue("70000")},
+			wantErr:   true,
+			wantParts: []string{"server.port: 70000", "database.host: must not be empty"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConfig(newConfigImplFromMap(tt.data), []ConfigValidator{portValidator, hostValidator}, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				return
+			}
+			if !errors.Is(err, ErrValidationFailed) {
+				t.Errorf("error = %v, want ErrValidationFailed", err)
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(err.Error(), part) {
+					t.Errorf("error %q should contain %q", err, part)
+				}
+			}
+		})
+	}
+}
+
+func TestManager_WithConfigValidator(t *testing.T) {
+	t.Run("initial_load_rejected", func(t *testing.T) {
+		m := NewManager(WithConfigValidator(portValidator))
+		m.AddSource(&mockSource{name: "file", data: map[string]Value{"server.port": NewValue("0")}})
+		if err := m.Load(context.Background()); !errors.Is(err, ErrValidationFailed) {
+			t.Errorf("Load() error = %v, want ErrValidationFailed", err)
+		}
+		if m.Config().Has("server.port") {
+			t.Error("rejected config should not be applied")
+		}
+	})
+
+	t.Run("reload_keeps_last_good", func(t *testing.T) {
+		watcher := &mockWatcher{eventCh: make(chan Event, 10)}
+		source := &mockSource{
+			name:    "file",
+			data:    map[string]Value{"server.port": NewValue("8080")},
+			watcher: watcher,
+		}
+
+		m := NewManager(WithConfigValidator(portValidator))
+		m.AddSource(source)
+		if err := m.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		events := make(chan Event, 10)
+		m.OnChange(func(event Event, oldConfig, newConfig Config) { events <- event })
+		if err := m.Watch(); err != nil {
+			t.Fatalf("Watch() error = %v", err)
+		}
+		defer m.Close()
+
+		source.data = map[string]Value{"server.port": NewValue("99999")}
+		watcher.eventCh <- Event{Type: EventTypeUpdate, Source: "file"}
+
+		select {
+		case event := <-events:
+			if event.Type != EventTypeError || !errors.Is(event.Error, ErrValidationFailed) {
+				t.Errorf("event = %+v, want validation error event", event)
+			}
+			if event.Source != "file" || !strings.Contains(event.Error.Error(), "99999") {
+				t.Errorf("error event should describe the violation: %v", event.Error)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for error event")
+		}
+
+		if got := m.Config().GetInt("server.port", 0); got != 8080 {
+			t.Errorf("server.port = %d, want last good value 8080", got)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -10,7 +11,8 @@ import (
 
 // Value 封装配置值，提供类型转换方法
 type Value struct {
-	raw any // 原始值
+	raw       any  // 原始值
+	sensitive bool // 敏感值在 fmt 输出中显示为 ******
 }
 
 // NewValue 从字符串创建 Value
@@ -28,7 +30,36 @@ func (v Value) Raw() any {
 	return v.raw
 }
 
+// AsSensitive 返回标记为敏感的副本
+// 敏感值在 fmt 格式化输出（%v、%s、%q 等）中显示为 ******，String() 仍返回真实内容
+func (v Value) AsSensitive() Value {
+	v.sensitive = true
+	return v
+}
+
+// Sensitive 是否为敏感值
+func (v Value) Sensitive() bool {
+	return v.sensitive
+}
+
+// Format 实现 fmt.Formatter，确保打印配置时不泄露敏感值
+func (v Value) Format(f fmt.State, verb rune) {
+	if v.sensitive {
+		fmt.Fprint(f, Redacted)
+		return
+	}
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprintf(f, "config.Value{%#v}", v.raw)
+	case verb == 'v' || verb == 's' || verb == 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), v.String())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, verb), v.raw)
+	}
+}
+
 // String 返回字符串表示
+// 返回真实内容，不做脱敏；日志输出请使用 fmt 格式化
 func (v Value) String() string {
 	if v.raw == nil {
 		return ""
@@ -41,142 +72,192 @@ func (v Value) String() string {
 	}
 }
 
-// Int 转换为整数
+// Int 转换为整数，浮点数截断小数部分
 func (v Value) Int(defaultVal int) int {
-	if v.raw == nil {
+	if f, ok := v.raw.(float64); ok {
+		return int(f)
+	}
+	i, err := v.IntE()
+	if err != nil {
 		return defaultVal
 	}
+	return i
+}
 
+// IntE 转换为整数，转换失败时返回 ErrTypeMismatch
+// 与 Int 不同，带小数部分的浮点数视为转换失败
+func (v Value) IntE() (int, error) {
 	switch val := v.raw.(type) {
 	case int:
-		return val
+		return val, nil
 	case int64:
-		return int(val)
+		return int(val), nil
 	case float64:
-		return int(val)
+		i, ok := floatToInt64(val)
+		if !ok || int64(int(i)) != i {
+			return 0, v.mismatch("int")
+		}
+		return int(i), nil
 	case string:
 		i, err := strconv.Atoi(val)
 		if err != nil {
-			return defaultVal
+			return 0, v.mismatch("int")
 		}
-		return i
+		return i, nil
 	default:
-		return defaultVal
+		return 0, v.mismatch("int")
 	}
 }
 
-// Int64 转换为 int64
+// Int64 转换为 int64，浮点数截断小数部分
 func (v Value) Int64(defaultVal int64) int64 {
-	if v.raw == nil {
+	if f, ok := v.raw.(float64); ok {
+		return int64(f)
+	}
+	i, err := v.Int64E()
+	if err != nil {
 		return defaultVal
 	}
+	return i
+}
 
+// Int64E 转换为 int64，转换失败时返回 ErrTypeMismatch
+// 与 Int64 不同，带小数部分的浮点数视为转换失败
+func (v Value) Int64E() (int64, error) {
 	switch val := v.raw.(type) {
 	case int64:
-		return val
+		return val, nil
 	case int:
-		return int64(val)
+		return int64(val), nil
 	case float64:
-		return int64(val)
+		i, ok := floatToInt64(val)
+		if !ok {
+			return 0, v.mismatch("int64")
+		}
+		return i, nil
 	case string:
 		i, err := strconv.ParseInt(val, 10, 64)
 		if err != nil {
-			return defaultVal
+			return 0, v.mismatch("int64")
 		}
-		return i
+		return i, nil
 	default:
-		return defaultVal
+		return 0, v.mismatch("int64")
 	}
 }
 
 // Float64 转换为浮点数
 func (v Value) Float64(defaultVal float64) float64 {
-	if v.raw == nil {
+	f, err := v.Float64E()
+	if err != nil {
 		return defaultVal
 	}
+	return f
+}
 
+// Float64E 转换为浮点数，转换失败时返回 ErrTypeMismatch
+func (v Value) Float64E() (float64, error) {
 	switch val := v.raw.(type) {
 	case float64:
-		return val
+		return val, nil
 	case float32:
-		return float64(val)
+		return float64(val), nil
 	case int:
-		return float64(val)
+		return float64(val), nil
 	case int64:
-		return float64(val)
+		return float64(val), nil
 	case string:
 		f, err := strconv.ParseFloat(val, 64)
 		if err != nil {
-			return defaultVal
+			return 0, v.mismatch("float64")
 		}
-		return f
+		return f, nil
 	default:
-		return defaultVal
+		return 0, v.mismatch("float64")
 	}
 }
 
 // Bool 转换为布尔值
 func (v Value) Bool(defaultVal bool) bool {
-	if v.raw == nil {
+	b, err := v.BoolE()
+	if err != nil {
 		return defaultVal
 	}
+	return b
+}
 
+// BoolE 转换为布尔值，转换失败时返回 ErrTypeMismatch
+func (v Value) BoolE() (bool, error) {
 	switch val := v.raw.(type) {
 	case bool:
-		return val
+		return val, nil
 	case string:
 		b, err := strconv.ParseBool(val)
 		if err != nil {
-			return defaultVal
+			return false, v.mismatch("bool")
 		}
-		return b
+		return b, nil
 	default:
-		return defaultVal
+		return false, v.mismatch("bool")
 	}
 }
 
 // Duration 转换为时间间隔
 func (v Value) Duration(defaultVal time.Duration) time.Duration {
-	if v.raw == nil {
+	d, err := v.DurationE()
+	if err != nil {
 		return defaultVal
 	}
+	return d
+}
 
+// DurationE 转换为时间间隔，转换失败时返回 ErrTypeMismatch
+// 整数按纳秒处理
+func (v Value) DurationE() (time.Duration, error) {
 	switch val := v.raw.(type) {
 	case time.Duration:
-		return val
+		return val, nil
+	case int:
+		return time.Duration(val), nil
 	case int64:
-		return time.Duration(val)
+		return time.Duration(val), nil
 	case string:
 		d, err := time.ParseDuration(val)
 		if err != nil {
-			return defaultVal
+			return 0, v.mismatch("duration")
 		}
-		return d
+		return d, nil
 	default:
-		return defaultVal
+		return 0, v.mismatch("duration")
 	}
 }
 
 // StringSlice 转换为字符串切片
 func (v Value) StringSlice(defaultVal []string) []string {
-	if v.raw == nil {
+	s, err := v.StringSliceE()
+	if err != nil {
 		return defaultVal
 	}
+	return s
+}
 
+// StringSliceE 转换为字符串切片，转换失败时返回 ErrTypeMismatch
+// 字符串支持 JSON 数组格式或逗号分隔格式，不包含任何元素的字符串视为转换失败
+func (v Value) StringSliceE() ([]string, error) {
 	switch val := v.raw.(type) {
 	case []string:
-		return val
+		return val, nil
 	case []any:
 		result := make([]string, 0, len(val))
 		for _, item := range val {
 			result = append(result, fmt.Sprintf("%v", item))
 		}
-		return result
+		return result, nil
 	case string:
 		// 尝试 JSON 解析
 		var slice []string
 		if err := json.Unmarshal([]byte(val), &slice); err == nil {
-			return slice
+			return slice, nil
 		}
 		// 尝试逗号分隔
 		parts := strings.Split(val, ",")
@@ -187,36 +268,57 @@ func (v Value) StringSlice(defaultVal []string) []string {
 			}
 		}
 		if len(result) > 0 {
-			return result
+			return result, nil
 		}
-		return defaultVal
+		return nil, v.mismatch("[]string")
 	default:
-		return defaultVal
+		return nil, v.mismatch("[]string")
 	}
 }
 
 // StringMap 转换为字符串映射
 func (v Value) StringMap(defaultVal map[string]string) map[string]string {
-	if v.raw == nil {
+	m, err := v.StringMapE()
+	if err != nil {
 		return defaultVal
 	}
+	return m
+}
 
+// StringMapE 转换为字符串映射，转换失败时返回 ErrTypeMismatch
+func (v Value) StringMapE() (map[string]string, error) {
 	switch val := v.raw.(type) {
 	case map[string]string:
-		return val
+		return val, nil
 	case map[string]any:
 		result := make(map[string]string, len(val))
 		for k, v := range val {
 			result[k] = fmt.Sprintf("%v", v)
 		}
-		return result
+		return result, nil
 	case string:
 		var m map[string]string
 		if err := json.Unmarshal([]byte(val), &m); err == nil {
-			return m
+			return m, nil
 		}
-		return defaultVal
+		return nil, v.mismatch("map[string]string")
 	default:
-		return defaultVal
+		return nil, v.mismatch("map[string]string")
+	}
+}
+
+// floatToInt64 将浮点数转换为 int64，带小数部分或超出范围时返回 false
+func floatToInt64(f float64) (int64, bool) {
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// mismatch 构造类型转换错误，错误信息中包含原始值便于排查
+func (v Value) mismatch(target string) error {
+	if v.raw == nil {
+		return fmt.Errorf("%w: cannot convert nil to %s", ErrTypeMismatch, target)
 	}
+	return fmt.Errorf("%w: cannot convert %q (%T) to %s", ErrTypeMismatch, v, v.raw, target)
 }
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"errors"
+	"math"
+	"strings"
 	"testing"
 	"time"
 )
@@ -84,6 +87,7 @@ func TestValue_Duration(t *testing.T) {
 		{"duration", time.Second * 30, 30 * time.Second},
 		{"string_duration", "1m30s", 90 * time.Second},
 		{"int64_ns", int64(1000000000), time.Second},
+		{"int_ns", int(1000000000), time.Second},
 	}
 
 	for _, tt := range tests {
@@ -165,6 +169,74 @@ func TestValue_StringMap(t *testing.T) {
 	}
 }
 
+// TestValue_StrictConversions 测试返回错误的转换方法
+func TestValue_StrictConversions(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		if got, err := NewValue("42").IntE(); err != nil || got != 42 {
+			t.Errorf("IntE() = %v, %v", got, err)
+		}
+		if got, err := NewValueFromInterface(int64(7)).Int64E(); err != nil || got != 7 {
+			t.Errorf("Int64E() = %v, %v", got, err)
+		}
+		if got, err := NewValue("2.5").Float64E(); err != nil || got != 2.5 {
+			t.Errorf("Float64E() = %v, %v", got, err)
+		}
+		if got, err := NewValue("true").BoolE(); err != nil || !got {
+			t.Errorf("BoolE() = %v, %v", got, err)
+		}
+		if got, err := NewValue("1m").DurationE(); err != nil || got != time.Minute {
+			t.Errorf("DurationE() = %v, %v", got, err)
+		}
+		if got, err := NewValue("a,b").StringSliceE(); err != nil || len(got) != 2 {
+			t.Errorf("StringSliceE() = %v, %v", got, err)
+		}
+		if got, err := NewValue(`{"a":"b"}`).StringMapE(); err != nil || got["a"] != "b" {
+			t.Errorf("StringMapE() = %v, %v", got, err)
+		}
+	})
+
+	t.Run("fraction_truncated_by_default_getters", func(t *testing.T) {
+		v := NewValueFromInterface(3.7)
+		if got := v.Int64(-1); got != 3 {
+			t.Errorf("Int64() = %v, want 3", got)
+		}
+		if _, err := v.Int64E(); !errors.Is(err, ErrTypeMismatch) {
+			t.Errorf("Int64E() error = %v, want ErrTypeMismatch", err)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		convert func(Value) error
+		input   any
+	}{
+		{"int", func(v Value) error { _, err := v.IntE(); return err }, "abc"},
+		{"int64", func(v Value) error { _, err := v.Int64E(); return err }, true},
+		{"int_fraction", func(v Value) error { _, err := v.IntE(); return err }, 3.7},
+		{"int64_fraction", func(v Value) error { _, err := v.Int64E(); return err }, -0.5},
+		{"int64_overflow", func(v Value) error { _, err := v.Int64E(); return err }, 1e19},
+		{"int64_nan", func(v Value) error { _, err := v.Int64E(); return err }, math.NaN()},
+		{"float64", func(v Value) error { _, err := v.Float64E(); return err }, "xyz"},
+		{"bool", func(v Value) error { _, err := v.BoolE(); return err }, "notabool"},
+		{"duration", func(v Value) error { _, err := v.DurationE(); return err }, "30 s"},
+		{"string_slice", func(v Value) error { _, err := v.StringSliceE(); return err }, "  "},
+		{"string_map", func(v Value) error { _, err := v.StringMapE(); return err }, "{broken"},
+		{"nil", func(v Value) error { _, err := v.IntE(); return err }, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.convert(NewValueFromInterface(tt.input))
+			if !errors.Is(err, ErrTypeMismatch) {
+				t.Fatalf("error = %v, want ErrTypeMismatch", err)
+			}
+			if s, ok := tt.input.(string); ok && !strings.Contains(err.Error(), s) {
+				t.Errorf("error %q should contain raw value %q", err, s)
+			}
+		})
+	}
+}
+
 // TestValue_EdgeCases 测试边界情况
 func TestValue_EdgeCases(t *testing.T) {
 	t.Run("nil_value", func(t *testing.T) {